@@ -0,0 +1,687 @@
+// Package autocomplete provides AutoComplete, an Entry that displays a list of suggestions,
+// just like the one in fyne-x but improved (simpler code, multiligne items...)
+package autocomplete
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// AutoComplete is an extended widget.Entry that can display a list of suggestions
+// in a list.
+//
+// Set a Provider and the AutoComplete will populate and show the list by itself
+// each time the text changes. Or leave it nil and control the options displayed
+// and when they are displayed (set Options and call ListShow in OnChanged).
+//
+// By default, list items display the parts of the option matching the query
+// (see FuzzyMatch) in bold. You can also use custom CanvasObjects as list items.
+//
+// You can navigate through the suggested items and select them with mouse
+// or keyboard (up and down arrow, page up and down, home and end, enter to select,
+// escape to hide the list). Other keys are typed in the Entry.
+//
+// Each Option can carry the object it represents (Value), OnCompleted receives
+// the chosen one and can override what will be completed (just return something
+// different than its Label).
+//
+// Depending on Mode, the best suggestion can also be displayed inline as grey
+// text after the typed text, accepted with Tab or Right arrow.
+//
+// With a History, the values completed or submitted are recorded and suggested
+// when the AutoComplete is focused empty (Delete removes the selected one).
+//
+// In MultiValue mode, completed values are displayed as removable tokens under
+// the text (tags, recipients...) and the text is cleared to type the next one.
+//
+// In Strict mode, the text must be one of the options (foreign keys...): Selected returns
+// the chosen one, and any other text is flagged as invalid (see Validate, works with widget.Form).
+//
+// With Triggers, only the word under the cursor starting with one of them is completed
+// (@mentions, #tags... in a multiline Entry): the popup is displayed under this word,
+// and completing replaces only this word.
+//
+// The text and the suggested values can be bound to data (see NewAutoCompleteWithData).
+type AutoComplete struct {
+	widget.Entry
+
+	OnChanged   func(string) // Called when the text changes, before the Provider is queried
+	OnSubmitted func(string) // Called when Enter is pressed in the input, or on completion if SubmitOnCompleted
+
+	// autocomplete
+	Provider          SuggestionProvider // if set, queried on each text change to populate Options
+	Debounce          time.Duration      // delay after the last keystroke before querying the Provider
+	PageSize          int                // options requested per page from a PagedSuggestionProvider (DefaultPageSize if 0)
+	Options           []Option
+	OnCompleted       func(Option) string
+	SubmitOnCompleted bool           // if true, completing from list triggers OnSubmited
+	Mode              CompletionMode // popup list, inline completion, or both
+	Placement         PopupPlacement // where to display the popup list
+	History           *History       // if set, records accepted values and suggests them when empty
+	Strict            bool           // if true, only the options are valid values (see Validate and Selected)
+	Triggers          []rune         // if set, only the word under the cursor starting with one of them is completed
+
+	MultiValue      bool           // if true, completing from list adds a token instead of replacing the text
+	OnValuesChanged func([]string) // Called when a token is added or removed (MultiValue mode)
+
+	CustomCreate func() fyne.CanvasObject
+	CustomUpdate func(id widget.ListItemID, co fyne.CanvasObject)
+
+	list  *autoCompleteList
+	pause bool
+
+	ui sync.Mutex // serializes the UI events, the lookup results and data changes display, see uiEvent
+
+	// The lookup goroutines write the Provider results, and the list can be rendered outside
+	// of the UI goroutine: mu protects Options and the fields below, read and written under it.
+	mu              sync.Mutex
+	events          int // UI event handlers running, see uiEvent
+	popup           *widget.PopUp
+	popupCanvasSize fyne.Size // canvas size when the popup was laid out
+	selected        widget.ListItemID
+	query           string             // text the Options were computed for
+	showingHistory  bool               // Options are the History values
+	cancel          context.CancelFunc // cancels the pending Provider lookup
+	loading         bool               // a Provider lookup is pending
+	running         int                // lookup goroutines not finished, results displayed included
+	page            page               // paging state, if the Provider is a PagedSuggestionProvider
+	tok             *token             // word being completed (Triggers mode)
+	ghost           string             // inline completion, after typed
+	typed           string             // text when the inline completion was last updated
+	typedEnd        bool               // the cursor was at the end of typed
+
+	prevText string // text before the last change, to find the cursor position (Triggers mode)
+
+	values []string        // MultiValue tokens
+	tokens *fyne.Container // tokens buttons, nil until rendered
+
+	completed       *Option // last option completed from the list
+	completedText   string  // text completed for it
+	strictValidator bool    // Validator wrapped, see setupStrictValidator
+
+	text            binding.String // bound text, see Bind
+	textListener    binding.DataListener
+	textError       error              // conversion error of the bound text, under mu
+	optionsData     binding.StringList // bound options, see BindOptions
+	optionsListener binding.DataListener
+}
+
+// NewAutoComplete creates a new AutoComplete.
+// If minLines > 1, it will be a multiline entry showing at least minLines rows.
+func NewAutoComplete(minLines int) *AutoComplete {
+	ac := &AutoComplete{selected: -1}
+	ac.ExtendBaseWidget(ac)
+	ac.list = newAutoCompleteList(ac)
+	ac.Entry.OnChanged = ac.onChanged
+	ac.Entry.OnSubmitted = ac.onSubmitted
+	if minLines > 1 {
+		ac.Entry.MultiLine = true
+		ac.Entry.Wrapping = fyne.TextWrapWord
+		ac.Entry.SetMinRowsVisible(minLines)
+	} else {
+		ac.Entry.Wrapping = fyne.TextTruncate
+	}
+	return ac
+}
+
+func (ac *AutoComplete) AcceptsTab() bool { return ac.Entry.MultiLine || ac.getGhost() != "" }
+
+func (ac *AutoComplete) CreateRenderer() fyne.WidgetRenderer {
+	ac.setupStrictValidator()
+	return newAutoCompleteRenderer(ac, ac.Entry.CreateRenderer())
+}
+
+func (ac *AutoComplete) TypedRune(r rune) {
+	defer ac.uiEvent()()
+	ac.Entry.TypedRune(r)
+}
+
+func (ac *AutoComplete) TypedShortcut(s fyne.Shortcut) {
+	defer ac.uiEvent()()
+	ac.Entry.TypedShortcut(s)
+}
+
+func (ac *AutoComplete) TypedKey(k *fyne.KeyEvent) {
+	defer ac.uiEvent()()
+	switch k.Name {
+	case fyne.KeyTab, fyne.KeyRight:
+		if ac.acceptGhost() {
+			return
+		}
+	case fyne.KeyEscape:
+		ac.setGhost("")
+	}
+	switch k.Name {
+	case fyne.KeyLeft, fyne.KeyRight, fyne.KeyHome, fyne.KeyEnd, fyne.KeyPageUp, fyne.KeyPageDown:
+		if ac.token() != nil {
+			ac.setToken(nil) // the cursor leaves the word being completed
+			ac.ListHide()
+		}
+	}
+	if ac.MultiValue && k.Name == fyne.KeyBackspace && ac.Text == "" && len(ac.values) > 0 {
+		ac.removeValue(len(ac.values) - 1)
+		return
+	}
+	ac.Entry.TypedKey(k)
+	if ac.Mode != CompletionPopup && k.Name != fyne.KeyEscape {
+		ac.updateGhost() // the cursor may have moved
+	}
+}
+
+func (ac *AutoComplete) FocusGained() {
+	defer ac.uiEvent()()
+	ac.Entry.FocusGained()
+	if ac.Text == "" && !ac.ListVisible() {
+		ac.showHistory() // the list is focused by the popup focus manager, not the one changing
+	}
+}
+
+func (ac *AutoComplete) FocusLost() {
+	defer ac.uiEvent()()
+	if !ac.ListVisible() {
+		ac.setGhost("")
+		ac.strictFocusLost()
+	}
+	ac.Entry.FocusLost()
+}
+
+// uiEvent is called by the UI event handlers, that call the returned function when they end:
+// the lookup results are not displayed meanwhile (see applyResults). The handlers are nested
+// when an event causes another one (focus change...), only the outer one locks.
+// Outside of the UI goroutine (lookup results, data listeners), ui is locked directly.
+func (ac *AutoComplete) uiEvent() func() {
+	ac.mu.Lock()
+	ac.events++
+	outer := ac.events == 1
+	ac.mu.Unlock()
+	if outer {
+		ac.ui.Lock()
+	}
+	return func() {
+		ac.mu.Lock()
+		ac.events--
+		last := ac.events == 0
+		ac.mu.Unlock()
+		if last {
+			ac.ui.Unlock()
+		}
+	}
+}
+
+// ---
+
+func (ac *AutoComplete) ListShow() {
+	if ac.pause {
+		return
+	}
+	if res := ac.results(); len(res.options) <= 0 && !res.loading {
+		ac.ListHide()
+		return
+	}
+
+	cnv := fyne.CurrentApp().Driver().CanvasForObject(ac)
+	if cnv == nil {
+		return // not show
+	}
+
+	popup := ac.getPopup()
+	if popup == nil {
+		popup = widget.NewPopUp(ac.list, cnv)
+		ac.mu.Lock()
+		ac.popup = popup
+		ac.mu.Unlock()
+	}
+
+	popup.Show()
+	ac.popupRelayout()
+	ac.refreshList()
+	cnv.Focus(ac.list)
+}
+
+// ListHide hides the suggestions list (and inline completion), cancelling any pending Provider lookup.
+func (ac *AutoComplete) ListHide() {
+	ac.cancelSuggest()
+	ac.setGhost("")
+	ac.mu.Lock()
+	ac.showingHistory = false
+	popup := ac.popup
+	ac.mu.Unlock()
+	if popup != nil {
+		ac.list.UnselectAll()
+		popup.Hide()
+	}
+}
+
+func (ac *AutoComplete) ListVisible() bool {
+	popup := ac.getPopup()
+	return popup != nil && popup.Visible()
+}
+
+func (ac *AutoComplete) getPopup() *widget.PopUp {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	return ac.popup
+}
+
+// refreshList displays the Options in the list, and selects the first selectable one.
+func (ac *AutoComplete) refreshList() {
+	ac.list.Refresh()
+	if id := ac.nextSelectable(-1, 1); id >= 0 {
+		ac.list.Select(id)
+	} else {
+		ac.list.UnselectAll()
+	}
+}
+
+func (ac *AutoComplete) SetText(s string) {
+	ac.pause = true
+	ac.Entry.SetText(s)
+	ac.pause = false
+}
+
+func (ac *AutoComplete) Move(pos fyne.Position) {
+	ac.Entry.Move(pos)
+	if ac.ListVisible() {
+		ac.popupRelayout()
+	}
+}
+
+func (ac *AutoComplete) Resize(size fyne.Size) {
+	ac.Entry.Resize(size)
+	if ac.ListVisible() {
+		ac.popupRelayout()
+	}
+}
+
+func (ac *AutoComplete) onChanged(s string) {
+	if len(ac.Triggers) > 0 {
+		ac.updateToken(s)
+	} else if !ac.pause {
+		ac.setQuery(s)
+	}
+	if ac.OnChanged != nil {
+		ac.OnChanged(s)
+	}
+	if ac.Provider == nil || ac.pause {
+		return
+	}
+	if s == "" {
+		if !ac.showHistory() {
+			ac.ListHide()
+		}
+		return
+	}
+	if len(ac.Triggers) > 0 {
+		if ac.token() == nil {
+			ac.ListHide()
+			return
+		}
+		s = ac.results().query
+	}
+	ac.lookup(s)
+}
+
+func (ac *AutoComplete) setQuery(s string) {
+	ac.mu.Lock()
+	ac.query = s
+	ac.mu.Unlock()
+}
+
+// lookup queries the Provider for query in the background, displaying the loading row meanwhile.
+func (ac *AutoComplete) lookup(query string) {
+	ctx := ac.startLookup()
+	ac.showSuggestions() // displays the loading row
+	go ac.suggest(ctx, query, 0)
+}
+
+// startLookup cancels the pending Provider lookup, and returns the context of the next one.
+// The goroutine started for it must call lookupDone.
+func (ac *AutoComplete) startLookup() context.Context {
+	ctx := context.Background()
+	if tok := ac.token(); tok != nil {
+		ctx = context.WithValue(ctx, triggerKey{}, tok.trigger)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	ac.mu.Lock()
+	if ac.cancel != nil {
+		ac.cancel()
+	}
+	ac.cancel = cancel
+	ac.loading = true
+	ac.running++
+	ac.mu.Unlock()
+	return ctx
+}
+
+func (ac *AutoComplete) lookupDone() {
+	ac.mu.Lock()
+	ac.running--
+	ac.mu.Unlock()
+}
+
+// suggest runs outside of the UI goroutine: the Provider results are kept local,
+// and given to applyResults.
+// If offset > 0, the next page of a PagedSuggestionProvider is appended to the Options.
+func (ac *AutoComplete) suggest(ctx context.Context, query string, offset int) {
+	defer ac.lookupDone()
+	if ac.Debounce > 0 && offset == 0 {
+		select {
+		case <-time.After(ac.Debounce):
+		case <-ctx.Done():
+			return
+		}
+	}
+
+	var options []Option
+	total := -1
+	paged, isPaged := ac.Provider.(PagedSuggestionProvider)
+	if isPaged {
+		options, total = paged.SuggestPage(ctx, query, offset, ac.pageSize())
+	} else {
+		options = ac.Provider.Suggest(ctx, query)
+	}
+	ac.applyResults(ctx, options, offset, isPaged, total)
+}
+
+// applyResults is the only place the Provider results are written: serially with the UI events,
+// and under mu, where they are discarded if the lookup is stale (the user typed again, or the
+// list was hidden). The list shown by lookup and the inline completion are then updated.
+func (ac *AutoComplete) applyResults(ctx context.Context, options []Option, offset int, paged bool, total int) {
+	ac.ui.Lock()
+	defer ac.ui.Unlock()
+	ac.mu.Lock()
+	if ctx.Err() != nil {
+		ac.mu.Unlock()
+		return
+	}
+	ac.cancel()
+	ac.cancel = nil
+	if offset > 0 {
+		ac.Options = append(ac.Options, options...)
+	} else {
+		ac.Options = options
+	}
+	ac.setPage(paged, offset, len(options), total)
+	ac.showingHistory = false
+	ac.loading = false
+	ac.mu.Unlock()
+
+	if offset > 0 {
+		ac.showNextPage()
+	} else {
+		ac.showResults()
+	}
+}
+
+func (ac *AutoComplete) showSuggestions() {
+	if ac.Mode != CompletionInline {
+		ac.ListShow()
+	}
+	ac.updateGhost()
+}
+
+// showResults displays the results of a lookup in the list, if shown (showing it would move
+// the focus outside of the UI goroutine), and updates the inline completion.
+// A Strict text, validated against the previous options, is validated again.
+func (ac *AutoComplete) showResults() {
+	if ac.ListVisible() {
+		if len(ac.options()) == 0 {
+			ac.ListHide()
+		} else {
+			ac.popupRelayout()
+			ac.refreshList()
+		}
+	}
+	ac.refreshGhost()
+	if ac.Strict {
+		ac.Validate()
+	}
+}
+
+func (ac *AutoComplete) onSubmitted(s string) {
+	if ac.History != nil && (!ac.Strict || ac.Validate() == nil) {
+		ac.History.Add(s)
+	}
+	if ac.OnSubmitted != nil {
+		ac.OnSubmitted(s)
+	}
+}
+
+func (ac *AutoComplete) cancelSuggest() {
+	ac.mu.Lock()
+	if ac.cancel != nil {
+		ac.cancel()
+		ac.cancel = nil
+	}
+	ac.loading = false
+	ac.mu.Unlock()
+}
+
+// options returns the Options, replaced by the lookup goroutines.
+func (ac *AutoComplete) options() []Option {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	return ac.Options
+}
+
+// results is a snapshot of the suggestions state, taken under mu.
+type results struct {
+	options []Option
+	query   string
+	loading bool
+	history bool
+	page    page
+}
+
+func (ac *AutoComplete) results() results {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	return results{ac.Options, ac.query, ac.loading, ac.showingHistory, ac.page}
+}
+
+func (ac *AutoComplete) getSelected() widget.ListItemID {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	return ac.selected
+}
+
+func (ac *AutoComplete) setSelected(id widget.ListItemID) {
+	ac.mu.Lock()
+	ac.selected = id
+	ac.mu.Unlock()
+}
+
+func (ac *AutoComplete) setTextFromList(opt Option) {
+	ac.ListHide()
+	ac.pause = true
+	s := opt.Label
+	if ac.OnCompleted != nil {
+		s = ac.OnCompleted(opt)
+	}
+	if ac.History != nil {
+		ac.History.Add(s)
+	}
+	if ac.token() != nil {
+		ac.replaceToken(s)
+		ac.pause = false
+	} else if ac.MultiValue {
+		ac.Entry.Text = ""
+		ac.Entry.CursorColumn = 0
+		ac.Entry.Refresh()
+		ac.pause = false
+		ac.addValue(s)
+	} else {
+		ac.Entry.Text = s
+		ac.Entry.CursorColumn = len([]rune(s))
+		ac.Entry.Refresh()
+		ac.pause = false
+		ac.completed, ac.completedText = &opt, s
+	}
+	ac.writeData()
+	ac.Validate()
+	if ac.SubmitOnCompleted && ac.OnSubmitted != nil {
+		ac.OnSubmitted(s)
+	}
+}
+
+// selectable reports whether Options[id] can be selected (not a section header).
+func (ac *AutoComplete) selectable(id widget.ListItemID) bool {
+	return selectable(ac.options(), id)
+}
+
+func selectable(options []Option, id widget.ListItemID) bool {
+	return id >= 0 && id < len(options) && !options[id].Header
+}
+
+// nextSelectable returns the first selectable option after from, going in step direction
+// and wrapping around, or -1 if there is none. Use from = -1 to start from the beginning.
+func (ac *AutoComplete) nextSelectable(from widget.ListItemID, step int) widget.ListItemID {
+	options := ac.options()
+	n := len(options)
+	if from < 0 && step < 0 {
+		from = 0
+	}
+	for i := 1; i <= n; i++ {
+		id := ((from+step*i)%n + n) % n
+		if selectable(options, id) {
+			return id
+		}
+	}
+	return -1
+}
+
+// selectableFrom returns the first selectable option from id (included) going in step direction
+// without wrapping around, or going in the other direction if there is none. It returns -1
+// if there is no selectable option.
+func (ac *AutoComplete) selectableFrom(id widget.ListItemID, step int) widget.ListItemID {
+	options := ac.options()
+	n := len(options)
+	if id >= n {
+		id = n - 1
+	}
+	if id < 0 {
+		id = 0
+	}
+	for _, step := range []int{step, -step} {
+		for i := id; i >= 0 && i < n; i += step {
+			if selectable(options, i) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// PopupPlacement defines where the suggestions list is displayed.
+type PopupPlacement int
+
+const (
+	// PlacementAuto displays the list below the Entry, or above it if it doesn't fit and there is more room there.
+	PlacementAuto PopupPlacement = iota
+	// PlacementBelow always displays the list below the Entry.
+	PlacementBelow
+	// PlacementAbove always displays the list above the Entry.
+	PlacementAbove
+)
+
+// popupRelayout moves and resizes the popup according to popupLayout.
+func (ac *AutoComplete) popupRelayout() {
+	cnv := fyne.CurrentApp().Driver().CanvasForObject(ac)
+	if cnv == nil {
+		return
+	}
+	pos, size := ac.popupLayout()
+	ac.mu.Lock()
+	ac.popupCanvasSize = cnv.Size()
+	popup := ac.popup
+	ac.mu.Unlock()
+	popup.Move(pos)
+	popup.Resize(size)
+}
+
+// popupLayout returns the absolute position and the size of the popup: as wide as the
+// longest item (at least as the Entry), as high as the items (at most the room available),
+// below or above the Entry (or the word being completed, see popupAnchor) according to Placement,
+// and right aligned with the Entry if it would overflow the right border of the canvas.
+func (ac *AutoComplete) popupLayout() (fyne.Position, fyne.Size) {
+	cnv := fyne.CurrentApp().Driver().CanvasForObject(ac)
+	if cnv == nil {
+		return fyne.Position{}, fyne.Size{}
+	}
+
+	pos, acSize := ac.popupAnchor()
+	cnvSize := cnv.Size()
+	pad := theme.Padding()
+
+	// define size boundaries
+	roomBelow := cnvSize.Height - pos.Y - acSize.Height - 2*pad
+	roomAbove := pos.Y - 2*pad
+	minWidth := acSize.Width
+	maxWidth := cnvSize.Width - 2*pad
+
+	size := ac.measureList(fyne.Max(roomBelow, roomAbove))
+
+	above := false
+	switch ac.Placement {
+	case PlacementAbove:
+		above = true
+	case PlacementAuto:
+		above = size.Height > roomBelow && roomAbove > roomBelow
+	}
+	if above {
+		size.Height = fyne.Min(size.Height, roomAbove)
+	} else {
+		size.Height = fyne.Min(size.Height, roomBelow)
+	}
+
+	if size.Width < minWidth {
+		size.Width = minWidth
+	}
+	if size.Width > maxWidth {
+		size.Width = maxWidth
+	}
+
+	x := pos.X
+	if x+size.Width > cnvSize.Width-pad {
+		x = pos.X + acSize.Width - size.Width // right aligned with the Entry
+	}
+	if x < 0 {
+		x = 0
+	}
+	y := pos.Y + acSize.Height + pad
+	if above {
+		y = pos.Y - pad - size.Height
+	}
+	return fyne.NewPos(x, y), size
+}
+
+// maxMeasuredRows is the maximum number of rows measured to size the popup,
+// the others are measured when scrolled into view.
+const maxMeasuredRows = 50
+
+// measureList returns the size needed to display the list items,
+// measuring them until maxHeight or maxMeasuredRows is reached.
+func (ac *AutoComplete) measureList(maxHeight float32) fyne.Size {
+	// iterating items (section headers and loading row included) until the end or we reach maxHeight
+	var width, height float32
+	for i := 0; i < ac.list.Length() && i < maxMeasuredRows; i++ {
+		sz := ac.list.measureRow(i)
+		if sz.Width > width {
+			width = sz.Width
+		}
+		height += sz.Height + theme.Padding()
+		if height > maxHeight {
+			break
+		}
+	}
+	height += theme.Padding() // popup padding
+
+	width += 2 * theme.Padding() // let some padding on the triling end of the longest item
+	return fyne.NewSize(width, height)
+}
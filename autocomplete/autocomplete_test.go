@@ -0,0 +1,859 @@
+package autocomplete
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// newTestAutoComplete returns an AutoComplete at the top left of a 400x300 test window (half its width),
+// with its list showing options.
+func newTestAutoComplete(t *testing.T, options ...string) (*AutoComplete, fyne.Window) {
+	t.Helper()
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+
+	ac := NewAutoComplete(1)
+	w := test.NewWindow(container.NewBorder(container.NewGridWithColumns(2, ac), nil, nil, nil, nil))
+	w.SetPadded(false)
+	w.Resize(fyne.NewSize(400, 300))
+	t.Cleanup(w.Close)
+
+	if len(options) > 0 {
+		ac.Options = StringOptions(options...)
+		ac.ListShow()
+	}
+	return ac, w
+}
+
+func typeKey(ac *AutoComplete, name fyne.KeyName) {
+	ac.list.TypedKey(&fyne.KeyEvent{Name: name})
+}
+
+// waitFor waits for the lookups of ac to end (their results displayed), and for cond,
+// evaluated serially with the lookup results and the data changes.
+func waitFor(t *testing.T, ac *AutoComplete, cond func() bool) {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		ac.ui.Lock()
+		ac.mu.Lock()
+		idle := ac.running == 0
+		ac.mu.Unlock()
+		ok := idle && cond()
+		ac.ui.Unlock()
+		if ok {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("timeout")
+}
+
+func TestAutoComplete_ListShow(t *testing.T) {
+	ac, w := newTestAutoComplete(t, "one", "two", "three")
+
+	if !ac.ListVisible() {
+		t.Fatal("list should be visible")
+	}
+	if ac.selected != 0 {
+		t.Errorf("first option should be selected, got %d", ac.selected)
+	}
+	if w.Canvas().Focused() != ac.list {
+		t.Error("list should be focused")
+	}
+
+	ac.Options = nil
+	ac.ListShow()
+	if ac.ListVisible() {
+		t.Error("list should be hidden without options")
+	}
+}
+
+func TestAutoCompleteList_TypedKey_Navigation(t *testing.T) {
+	ac, _ := newTestAutoComplete(t, "one", "two", "three")
+
+	for _, want := range []int{1, 2, 0, 1} {
+		typeKey(ac, fyne.KeyDown)
+		if ac.selected != want {
+			t.Fatalf("down: expected %d, got %d", want, ac.selected)
+		}
+	}
+	for _, want := range []int{0, 2, 1} {
+		typeKey(ac, fyne.KeyUp)
+		if ac.selected != want {
+			t.Fatalf("up: expected %d, got %d", want, ac.selected)
+		}
+	}
+}
+
+func TestAutoCompleteList_TypedKey_Complete(t *testing.T) {
+	for _, key := range []fyne.KeyName{fyne.KeyReturn, fyne.KeyEnter} {
+		ac, _ := newTestAutoComplete(t, "one", "two", "three")
+		submitted := false
+		ac.OnSubmitted = func(string) { submitted = true }
+
+		typeKey(ac, fyne.KeyDown)
+		typeKey(ac, key)
+		if ac.Text != "two" {
+			t.Errorf("%s: expected text %q, got %q", key, "two", ac.Text)
+		}
+		if ac.CursorColumn != 3 {
+			t.Errorf("%s: cursor should be at the end, got %d", key, ac.CursorColumn)
+		}
+		if ac.ListVisible() {
+			t.Errorf("%s: list should be hidden", key)
+		}
+		if submitted {
+			t.Errorf("%s: OnSubmitted should not be called", key)
+		}
+	}
+}
+
+func TestAutoCompleteList_TypedKey_Hide(t *testing.T) {
+	for _, key := range []fyne.KeyName{fyne.KeyEscape, fyne.KeyTab} {
+		ac, _ := newTestAutoComplete(t, "one", "two")
+		typeKey(ac, key)
+		if ac.ListVisible() {
+			t.Errorf("%s: list should be hidden", key)
+		}
+		if ac.selected != -1 {
+			t.Errorf("%s: selection should be cleared, got %d", key, ac.selected)
+		}
+		if ac.Text != "" {
+			t.Errorf("%s: text should not change, got %q", key, ac.Text)
+		}
+	}
+}
+
+func TestAutoCompleteList_TypedRune(t *testing.T) {
+	ac, _ := newTestAutoComplete(t, "one")
+	test.Type(ac.list, "ab")
+	if ac.Text != "ab" {
+		t.Errorf("runes typed in the list should go to the entry, got %q", ac.Text)
+	}
+}
+
+func TestAutoCompleteListItem_Mouse(t *testing.T) {
+	ac, _ := newTestAutoComplete(t, "one", "two", "three")
+	item := ac.list.CreateItem().(*autoCompleteListItem)
+	ac.list.UpdateItem(2, item)
+
+	item.MouseIn(&desktop.MouseEvent{})
+	if ac.selected != 2 {
+		t.Errorf("hovered item should be selected, got %d", ac.selected)
+	}
+	test.Tap(item)
+	if ac.Text != "three" || ac.ListVisible() {
+		t.Errorf("tapped item should be completed, got %q", ac.Text)
+	}
+}
+
+func TestAutoComplete_SetText_Pause(t *testing.T) {
+	ac, _ := newTestAutoComplete(t)
+	ac.Options = StringOptions("one", "two")
+	queried := false
+	ac.Provider = SuggestionProviderFunc(func(context.Context, string) []Option {
+		queried = true
+		return nil
+	})
+	changed := ""
+	ac.OnChanged = func(s string) {
+		changed = s
+		ac.ListShow()
+	}
+
+	ac.SetText("on")
+	if changed != "on" {
+		t.Errorf("OnChanged should be called, got %q", changed)
+	}
+	if ac.ListVisible() {
+		t.Error("SetText should not show the list")
+	}
+	time.Sleep(20 * time.Millisecond)
+	if queried {
+		t.Error("SetText should not query the Provider")
+	}
+}
+
+func TestAutoComplete_OnCompleted(t *testing.T) {
+	ac, _ := newTestAutoComplete(t, "one", "two")
+	var completed Option
+	ac.OnCompleted = func(opt Option) string {
+		completed = opt
+		return "number " + opt.Label
+	}
+	changed := false
+	ac.OnChanged = func(string) { changed = true }
+
+	typeKey(ac, fyne.KeyReturn)
+	if completed.Label != "one" || completed.Value != "one" {
+		t.Errorf("OnCompleted should receive the selected option, got %v", completed)
+	}
+	if ac.Text != "number one" {
+		t.Errorf("expected rewritten text, got %q", ac.Text)
+	}
+	if changed {
+		t.Error("completing should not call OnChanged")
+	}
+}
+
+func TestAutoComplete_SubmitOnCompleted(t *testing.T) {
+	ac, _ := newTestAutoComplete(t, "one", "two")
+	ac.SubmitOnCompleted = true
+	ac.OnCompleted = func(opt Option) string { return opt.Label + "!" }
+	submitted := ""
+	ac.OnSubmitted = func(s string) { submitted = s }
+
+	typeKey(ac, fyne.KeyDown)
+	typeKey(ac, fyne.KeyReturn)
+	if submitted != "two!" {
+		t.Errorf("OnSubmitted should receive the completed text, got %q", submitted)
+	}
+}
+
+func TestAutoComplete_Provider(t *testing.T) {
+	ac, w := newTestAutoComplete(t)
+	ac.Provider = NewPrefixProvider(StringOptions("one", "two", "three"))
+	w.Canvas().Focus(ac)
+
+	test.Type(ac, "t")
+	waitFor(t, ac, func() bool { return len(ac.options()) == 2 && !ac.results().loading })
+	if !ac.ListVisible() || ac.Options[0].Label != "two" || ac.Options[1].Label != "three" {
+		t.Errorf("unexpected suggestions %v", ac.Options)
+	}
+
+	typeKey(ac, fyne.KeyBackspace)
+	if ac.ListVisible() {
+		t.Error("list should be hidden when the text is empty")
+	}
+}
+
+func TestAutoComplete_Provider_Stale(t *testing.T) {
+	ac, w := newTestAutoComplete(t)
+	release := make(chan struct{})
+	ac.Provider = SuggestionProviderFunc(func(ctx context.Context, query string) []Option {
+		if query == "a" {
+			<-release // slow lookup, will be outdated
+		}
+		return StringOptions(query + "1")
+	})
+	w.Canvas().Focus(ac)
+
+	test.Type(ac, "a")
+	if !ac.loading || ac.list.Length() != 1 {
+		t.Error("loading row should be displayed")
+	}
+	test.Type(ac.list, "b")
+	close(release)
+	waitFor(t, ac, func() bool { return !ac.results().loading })
+	if len(ac.Options) != 1 || ac.Options[0].Label != "ab1" {
+		t.Errorf("stale results should be discarded, got %v", ac.Options)
+	}
+}
+
+func TestAutoComplete_popupLayout(t *testing.T) {
+	ac, w := newTestAutoComplete(t, "one", "two")
+	cnv := w.Canvas()
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(ac)
+
+	// few short options: below, as wide as the entry, as high as the items
+	item := ac.list.CreateItem()
+	ac.list.UpdateItem(0, item)
+	popupPos, size := ac.popupLayout()
+	if want := pos.Add(fyne.NewPos(0, ac.Size().Height+theme.Padding())); popupPos != want {
+		t.Errorf("expected position %v, got %v", want, popupPos)
+	}
+	if size.Width != ac.Size().Width {
+		t.Errorf("expected entry width %f, got %f", ac.Size().Width, size.Width)
+	}
+	if want := 2*(item.MinSize().Height+theme.Padding()) + theme.Padding(); size.Height != want {
+		t.Errorf("expected height %f, got %f", want, size.Height)
+	}
+
+	// many options: clamped to the room under the entry
+	var many []string
+	for i := 0; i < 100; i++ {
+		many = append(many, "option")
+	}
+	ac.Options = StringOptions(many...)
+	_, size = ac.popupLayout()
+	if want := cnv.Size().Height - pos.Y - ac.Size().Height - 2*theme.Padding(); size.Height != want {
+		t.Errorf("expected height %f, got %f", want, size.Height)
+	}
+
+	// long option: clamped to the canvas width
+	ac.Options = StringOptions("a very long option that can't fit in the window, even if it is 400 pixels wide")
+	popupPos, size = ac.popupLayout()
+	if want := cnv.Size().Width - 2*theme.Padding(); size.Width != want {
+		t.Errorf("expected width %f, got %f", want, size.Width)
+	}
+	if popupPos.X != 0 {
+		t.Errorf("expected x 0, got %f", popupPos.X)
+	}
+}
+
+func TestAutoComplete_popupLayout_Placement(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+
+	// entry at the bottom right of the window
+	ac := NewAutoComplete(1)
+	w := test.NewWindow(container.NewBorder(nil, container.NewGridWithColumns(2, layout.NewSpacer(), ac), nil, nil, nil))
+	w.SetPadded(false)
+	w.Resize(fyne.NewSize(400, 300))
+	t.Cleanup(w.Close)
+	ac.Options = StringOptions("one", "two", "a long option, longer than the entry width")
+	ac.ListShow()
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(ac)
+
+	popupPos, size := ac.popupLayout()
+	if popupPos.Y+size.Height > pos.Y {
+		t.Errorf("popup should be above the entry, got y %f height %f (entry y %f)", popupPos.Y, size.Height, pos.Y)
+	}
+	if size.Width <= ac.Size().Width {
+		t.Fatalf("popup should be wider than the entry, got %f", size.Width)
+	}
+	if popupPos.X+size.Width != pos.X+ac.Size().Width {
+		t.Errorf("popup should be right aligned with the entry, got x %f width %f", popupPos.X, size.Width)
+	}
+
+	ac.Placement = PlacementBelow
+	popupPos, size = ac.popupLayout()
+	if popupPos.Y < pos.Y+ac.Size().Height {
+		t.Errorf("popup should be below the entry, got y %f", popupPos.Y)
+	}
+	if size.Height > w.Canvas().Size().Height-popupPos.Y {
+		t.Errorf("popup should fit in the room below, got height %f", size.Height)
+	}
+
+}
+
+func TestAutoComplete_popupLayout_CanvasResize(t *testing.T) {
+	ac, w := newTestAutoComplete(t, "one", "two")
+	pos := ac.Position()
+
+	w.Resize(fyne.NewSize(400, 600)) // the entry doesn't move nor resize
+	if ac.Position() != pos || ac.popupCanvasSize != w.Canvas().Size() {
+		t.Error("popup should be laid out again when the canvas is resized")
+	}
+}
+
+func TestAutoComplete_measureList_Large(t *testing.T) {
+	ac, _ := newTestAutoComplete(t)
+	created := 0
+	ac.CustomCreate = func() fyne.CanvasObject {
+		created++
+		return widget.NewLabel("")
+	}
+	ac.CustomUpdate = func(id widget.ListItemID, co fyne.CanvasObject) {
+		co.(*widget.Label).SetText(ac.Options[id].Label)
+	}
+
+	var many []string
+	for i := 0; i < 10000; i++ {
+		many = append(many, "option")
+	}
+	ac.Options = StringOptions(many...)
+	ac.ListShow()
+
+	created = 0
+	size := ac.measureList(1e6) // room for all the rows
+	if created != 0 {
+		t.Errorf("measuring should reuse the template item, %d items created", created)
+	}
+	rowHeight := ac.list.template.MinSize().Height
+	if want := maxMeasuredRows*(rowHeight+theme.Padding()) + theme.Padding(); size.Height != want {
+		t.Errorf("expected %d measured rows (height %f), got height %f", maxMeasuredRows, want, size.Height)
+	}
+	if len(ac.list.heights) > maxMeasuredRows+int(ac.list.Size().Height/rowHeight)+1 {
+		t.Errorf("only measured and displayed rows heights should be set, got %d", len(ac.list.heights))
+	}
+}
+
+func TestAutoComplete_Bind(t *testing.T) {
+	ac, _ := newTestAutoComplete(t)
+	text := binding.NewString()
+	ac.Bind(text)
+	updated := bindingUpdated(text)
+	<-updated
+	ac.Options = StringOptions("one", "two")
+
+	ac.SetText("typed")
+	<-updated
+	if v, _ := text.Get(); v != "typed" {
+		t.Errorf("text should be written into data, got %q", v)
+	}
+
+	ac.ListShow()
+	typeKey(ac, fyne.KeyDown)
+	typeKey(ac, fyne.KeyReturn)
+	<-updated
+	if v, _ := text.Get(); v != "two" {
+		t.Errorf("completed text should be written into data, got %q", v)
+	}
+
+	ac.Provider = NewPrefixProvider(StringOptions("three"))
+	text.Set("from data")
+	<-updated
+	waitFor(t, ac, func() bool { return ac.Text == "from data" })
+	if ac.ListVisible() {
+		t.Error("data changes should not query the Provider")
+	}
+}
+
+// bindingUpdated returns a channel receiving a value each time data changes, once the listeners
+// of the previous changes were called (they are queued in order, outside of the test goroutine).
+func bindingUpdated(data binding.DataItem) chan struct{} {
+	updated := make(chan struct{}, 10)
+	data.AddListener(binding.NewDataListener(func() { updated <- struct{}{} }))
+	return updated
+}
+
+func TestAutoComplete_BindOptions(t *testing.T) {
+	options := binding.NewStringList()
+	options.Set([]string{"one", "two"})
+	ac := NewAutoCompleteWithData(binding.NewString(), options)
+	updated := bindingUpdated(options)
+	<-updated
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+	w := test.NewWindow(container.NewBorder(container.NewGridWithColumns(2, ac), nil, nil, nil, nil))
+	w.Resize(fyne.NewSize(400, 300))
+	t.Cleanup(w.Close)
+
+	test.Type(ac, "t")
+	waitFor(t, ac, func() bool { return ac.ListVisible() && len(ac.options()) == 1 && !ac.results().loading })
+	if ac.Options[0].Label != "two" {
+		t.Errorf("expected two, got %q", ac.Options[0].Label)
+	}
+
+	options.Append("three")
+	<-updated
+	waitFor(t, ac, func() bool { return len(ac.options()) == 2 && !ac.results().loading })
+	if ac.Options[1].Label != "three" {
+		t.Errorf("suggestions should be updated when the options change, got %v", ac.Options)
+	}
+}
+
+func TestAutoComplete_Strict(t *testing.T) {
+	ac, w := newTestAutoComplete(t)
+	ac.Strict = true
+	ac.Options = StringOptions("one", "two")
+
+	ac.SetText("on")
+	if err := ac.Validate(); err != ErrNotAnOption {
+		t.Errorf("expected ErrNotAnOption, got %v", err)
+	}
+	if _, ok := ac.Selected(); ok {
+		t.Error("nothing should be selected")
+	}
+
+	ac.ListShow()
+	typeKey(ac, fyne.KeyReturn)
+	if opt, ok := ac.Selected(); !ok || opt.Label != "one" {
+		t.Errorf("expected one to be selected, got %v %v", opt, ok)
+	}
+	if err := ac.Validate(); err != nil {
+		t.Errorf("completed text should be valid, got %v", err)
+	}
+
+	// typed with another case, completed on focus loss
+	ac.SetText("TWO")
+	w.Canvas().Focus(ac)
+	w.Canvas().Unfocus()
+	if opt, ok := ac.Selected(); !ok || opt.Label != "two" || ac.Text != "two" {
+		t.Errorf("expected two to be completed, got %q %v", ac.Text, ok)
+	}
+
+	ac.SetText("")
+	if err := ac.Validate(); err != nil {
+		t.Errorf("empty text should be valid, got %v", err)
+	}
+}
+
+func TestAutoComplete_Strict_Provider(t *testing.T) {
+	ac, w := newTestAutoComplete(t)
+	ac.Strict = true
+	ac.Debounce = 20 * time.Millisecond
+	ac.Provider = NewPrefixProvider(StringOptions("one", "two"))
+	var validation error
+	ac.SetOnValidationChanged(func(err error) { validation = err })
+	w.Canvas().Focus(ac)
+
+	test.Type(ac, "one")
+	if ac.Validate(); validation != ErrNotAnOption {
+		t.Errorf("text should be invalid until the options are suggested, got %v", validation)
+	}
+	waitFor(t, ac, func() bool { return len(ac.options()) == 1 && !ac.results().loading })
+	waitFor(t, ac, func() bool { return validation == nil })
+}
+
+func TestAutoComplete_Strict_Form(t *testing.T) {
+	ac, w := newTestAutoComplete(t)
+	ac.Strict = true
+	ac.Options = StringOptions("one")
+	form := widget.NewForm(widget.NewFormItem("Value", ac))
+	w.SetContent(form)
+
+	ac.SetText("other")
+	if err := form.Validate(); err != ErrNotAnOption {
+		t.Errorf("form should be invalid, got %v", err)
+	}
+	ac.SetText("one")
+	if err := form.Validate(); err != nil {
+		t.Errorf("form should be valid, got %v", err)
+	}
+}
+
+func TestAutoComplete_Triggers(t *testing.T) {
+	ac, _ := newTestAutoComplete(t)
+	ac.Triggers = []rune{'@', '#'}
+	var trigger rune
+	ac.Provider = SuggestionProviderFunc(func(ctx context.Context, query string) []Option {
+		if query == "jo" {
+			trigger, _ = TriggerFromContext(ctx) // only the last lookup, the others run concurrently
+		}
+		return NewPrefixProvider(StringOptions("John", "Jane")).Suggest(ctx, query)
+	})
+
+	test.Type(ac, "hello jo")
+	if ac.ListVisible() {
+		t.Error("words without trigger should not be completed")
+	}
+
+	ac.SetText("")
+	test.Type(ac, "hello @jo")
+	waitFor(t, ac, func() bool { return ac.ListVisible() && !ac.results().loading && ac.getSelected() == 0 })
+	if ac.query != "jo" || trigger != '@' {
+		t.Errorf("expected query jo with trigger @, got %q %q", ac.query, trigger)
+	}
+	if len(ac.Options) != 1 || ac.Options[0].Label != "John" {
+		t.Fatalf("expected John, got %v", ac.Options)
+	}
+	pos, _ := ac.popupLayout()
+	if acPos := fyne.CurrentApp().Driver().AbsolutePositionForObject(ac); pos.X <= acPos.X {
+		t.Errorf("popup should be anchored at the word, got x %f", pos.X)
+	}
+
+	typeKey(ac, fyne.KeyReturn)
+	if ac.Text != "hello @John " {
+		t.Errorf("only the word should be replaced, got %q", ac.Text)
+	}
+	if ac.CursorColumn != len("hello @John ") {
+		t.Errorf("cursor should be after the completed word, got %d", ac.CursorColumn)
+	}
+}
+
+func TestAutoComplete_Triggers_Middle(t *testing.T) {
+	ac, _ := newTestAutoComplete(t)
+	ac.Triggers = []rune{'#'}
+	ac.Provider = NewPrefixProvider(StringOptions("golang"))
+
+	ac.SetText("a  tag")
+	ac.CursorColumn = 2
+	test.Type(ac, "#go")
+	waitFor(t, ac, func() bool { return ac.ListVisible() && !ac.results().loading && ac.getSelected() == 0 })
+	typeKey(ac, fyne.KeyReturn)
+	if ac.Text != "a #golang tag" {
+		t.Errorf("expected the word to be replaced in the middle of the text, got %q", ac.Text)
+	}
+	if ac.CursorColumn != len("a #golang") {
+		t.Errorf("cursor should be after the completed word, got %d", ac.CursorColumn)
+	}
+}
+
+func TestAutoComplete_Paging(t *testing.T) {
+	ac, _ := newTestAutoComplete(t)
+	ac.Provider = PagedSuggestionProviderFunc(func(_ context.Context, query string, offset, limit int) ([]Option, int) {
+		var options []Option
+		for i := offset; i < offset+limit && i < 1204; i++ {
+			options = append(options, Option{Label: query + strconv.Itoa(i)})
+		}
+		return options, 1204
+	})
+	ac.PageSize = 50
+
+	test.Type(ac, "x")
+	waitFor(t, ac, func() bool { return len(ac.options()) == 50 && !ac.results().loading && ac.getSelected() == 0 })
+	if ac.page.footerText() != "Showing 50 of 1,204" {
+		t.Errorf("unexpected footer %q", ac.page.footerText())
+	}
+
+	typeKey(ac, fyne.KeyEnd)
+	if ac.getSelected() != 49 {
+		t.Fatalf("expected the last option to be selected, got %d", ac.selected)
+	}
+	waitFor(t, ac, func() bool { return len(ac.options()) == 100 && !ac.results().loading })
+	if ac.getSelected() != 49 {
+		t.Errorf("selection should be kept when the next page is loaded, got %d", ac.selected)
+	}
+	typeKey(ac, fyne.KeyDown)
+	if ac.getSelected() != 50 {
+		t.Errorf("expected the first option of the next page to be selected, got %d", ac.selected)
+	}
+}
+
+func TestFormatCount(t *testing.T) {
+	for n, want := range map[int]string{0: "0", 999: "999", 1204: "1,204", 1234567: "1,234,567", -1000: "-1,000"} {
+		if got := formatCount(n); got != want {
+			t.Errorf("formatCount(%d): expected %q, got %q", n, want, got)
+		}
+	}
+}
+
+func TestAutoCompleteList_TypedKey_Pages(t *testing.T) {
+	var labels []string
+	for i := 0; i < 100; i++ {
+		labels = append(labels, strconv.Itoa(i))
+	}
+	ac, _ := newTestAutoComplete(t, labels...)
+	ac.Options[99] = HeaderOption("not selectable")
+
+	page := ac.list.pageRows()
+	if page <= 1 || page >= 100 {
+		t.Fatalf("unexpected page size %d", page)
+	}
+	typeKey(ac, fyne.KeyPageDown)
+	if ac.selected != page {
+		t.Errorf("page down: expected %d, got %d", page, ac.selected)
+	}
+	typeKey(ac, fyne.KeyPageUp)
+	typeKey(ac, fyne.KeyPageUp)
+	if ac.selected != 0 {
+		t.Errorf("page up: expected 0, got %d", ac.selected)
+	}
+	if rowDisplayed(ac, 98) {
+		t.Fatal("the last rows should not be displayed at first")
+	}
+	typeKey(ac, fyne.KeyEnd)
+	if ac.selected != 98 {
+		t.Errorf("end: expected the last selectable option, got %d", ac.selected)
+	}
+	if !rowDisplayed(ac, 98) {
+		t.Error("end: the list should be scrolled to the selected option")
+	}
+	typeKey(ac, fyne.KeyPageDown)
+	if ac.selected != 98 {
+		t.Errorf("page down at the end: expected 98, got %d", ac.selected)
+	}
+	typeKey(ac, fyne.KeyHome)
+	if ac.selected != 0 || !rowDisplayed(ac, 0) {
+		t.Errorf("home: expected 0 scrolled into view, got %d", ac.selected)
+	}
+}
+
+// rowDisplayed reports whether the row id is displayed by the list.
+func rowDisplayed(ac *AutoComplete, id widget.ListItemID) bool {
+	for _, o := range test.LaidOutObjects(ac.list) {
+		if item, ok := o.(*autoCompleteListItem); ok && item != ac.list.template && item.id == id && item.Visible() {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAutoCompleteList_TypedRune_Cursor(t *testing.T) {
+	ac, w := newTestAutoComplete(t)
+	ac.SetText("helo")
+	ac.CursorColumn = 3
+	ac.Options = StringOptions("hello")
+	ac.ListShow()
+
+	ac.list.TypedRune('l')
+	typeKey(ac, fyne.KeyLeft)
+	ac.list.TypedRune('_')
+	if ac.Text != "hel_lo" || ac.CursorColumn != 4 {
+		t.Errorf("typing in the list should insert at the cursor, got %q (cursor %d)", ac.Text, ac.CursorColumn)
+	}
+	if w.Canvas().Focused() != ac.list {
+		t.Error("list should keep the focus")
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	for _, tt := range []struct {
+		query, s string
+		matches  []Match
+		ok       bool
+	}{
+		{"jsm", "jsm", []Match{{0, 3}}, true},
+		{"SMI", "John Smith", []Match{{5, 8}}, true},
+		{"ith", "John Smith", []Match{{7, 10}}, true},
+		{"jsm", "John Smith", []Match{{0, 1}, {5, 7}}, true},
+		{"jsm", "Smith John", nil, false},
+		{"", "John Smith", nil, true},
+	} {
+		_, matches, ok := FuzzyMatch(tt.query, tt.s)
+		if ok != tt.ok || len(matches) != len(tt.matches) {
+			t.Errorf("%q in %q: expected %v %v, got %v %v", tt.query, tt.s, tt.matches, tt.ok, matches, ok)
+			continue
+		}
+		for i := range matches {
+			if matches[i] != tt.matches[i] {
+				t.Errorf("%q in %q: expected %v, got %v", tt.query, tt.s, tt.matches, matches)
+			}
+		}
+	}
+
+	// best first: prefix, substring starting a word, substring, scattered
+	for _, tt := range []struct {
+		query  string
+		ranked []string
+	}{
+		{"jsm", []string{"jsm", "Mr jsm", "ajsm", "John Smith"}},
+		{"an", []string{"Anna", "Lee Ann", "Dan", "Aline"}},
+	} {
+		prev := 0
+		for i, s := range tt.ranked {
+			score, _, ok := FuzzyMatch(tt.query, s)
+			if !ok || i > 0 && score >= prev {
+				t.Errorf("%q: %q ranked %d (score %d, ok %v, previous %d)", tt.query, s, i, score, ok, prev)
+			}
+			prev = score
+		}
+	}
+}
+
+func TestAutoComplete_History(t *testing.T) {
+	ac, w := newTestAutoComplete(t)
+	for _, max := range []int{-1, 0} {
+		h := NewHistory(nil, "", max)
+		for i := 0; i < DefaultHistorySize+1; i++ {
+			h.Add(strconv.Itoa(i))
+		}
+		if values := h.Values(); len(values) != DefaultHistorySize || values[0] != strconv.Itoa(DefaultHistorySize) {
+			t.Errorf("max %d: expected the %d most recent values, got %v", max, DefaultHistorySize, values)
+		}
+	}
+
+	ac.History = NewHistory(nil, "", 5)
+	ac.History.Add("one")
+	w.Canvas().Focus(ac)
+	if !ac.ListVisible() || len(ac.options()) != 1 || w.Canvas().Focused() != ac.list {
+		t.Error("history should be shown when focused empty")
+	}
+}
+
+func TestAutoComplete_MultiValue(t *testing.T) {
+	ac, _ := newTestAutoComplete(t)
+	ac.MultiValue = true
+	var changed [][]string
+	ac.OnValuesChanged = func(values []string) { changed = append(changed, values) }
+
+	ac.Options = StringOptions("one", "two")
+	for _, down := range []bool{false, false, true} { // one, one again, two
+		ac.ListShow()
+		if down {
+			typeKey(ac, fyne.KeyDown)
+		}
+		typeKey(ac, fyne.KeyReturn)
+	}
+	if values := ac.Values(); len(values) != 2 || values[0] != "one" || values[1] != "two" || ac.Text != "" {
+		t.Errorf("completed values should be added once, got %v (text %q)", values, ac.Text)
+	}
+	if len(changed) != 2 || len(ac.tokens.Objects) != 2 {
+		t.Errorf("expected 2 OnValuesChanged calls and tokens, got %v, %d", changed, len(ac.tokens.Objects))
+	}
+
+	ac.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+	if values := ac.Values(); len(values) != 1 || values[0] != "one" || len(changed) != 3 {
+		t.Errorf("backspace in the empty entry should remove the last value, got %v", values)
+	}
+	test.Tap(ac.tokens.Objects[0].(*widget.Button))
+	if len(ac.Values()) != 0 || len(changed) != 4 {
+		t.Errorf("tapped token should be removed, got %v", ac.Values())
+	}
+
+	ac.SetValues([]string{"three", "four"})
+	if len(ac.Values()) != 2 || len(ac.tokens.Objects) != 2 || len(changed) != 4 {
+		t.Errorf("SetValues should not call OnValuesChanged, got %v", changed)
+	}
+}
+
+func TestAutoComplete_Inline(t *testing.T) {
+	ac, w := newTestAutoComplete(t)
+	ac.Mode = CompletionInline
+	ac.Provider = NewPrefixProvider(StringOptions("one", "two", "three"))
+	w.Canvas().Focus(ac)
+	typeText := func(s string) {
+		ac.SetText("")
+		test.Type(ac, s)
+		waitFor(t, ac, func() bool { return !ac.results().loading })
+	}
+
+	typeText("t")
+	if ghost := ac.getGhost(); ghost != "wo" || ac.ListVisible() {
+		t.Errorf("expected ghost wo without list, got %q", ghost)
+	}
+	ac.TypedKey(&fyne.KeyEvent{Name: fyne.KeyTab})
+	if ac.Text != "two" || ac.getGhost() != "" {
+		t.Errorf("tab should complete the ghost, got %q", ac.Text)
+	}
+
+	typeText("TH") // case insensitive
+	if ghost := ac.getGhost(); ghost != "ree" {
+		t.Errorf("expected ghost ree, got %q", ghost)
+	}
+	ac.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+	if ac.Text != "three" {
+		t.Errorf("right should complete the ghost, got %q", ac.Text)
+	}
+
+	typeText("o")
+	ac.TypedKey(&fyne.KeyEvent{Name: fyne.KeyEscape})
+	if ghost := ac.getGhost(); ghost != "" {
+		t.Errorf("escape should clear the ghost, got %q", ghost)
+	}
+
+	typeText("o")
+	ac.TypedKey(&fyne.KeyEvent{Name: fyne.KeyLeft})
+	if ghost := ac.getGhost(); ghost != "" {
+		t.Errorf("no ghost when the cursor is not at the end, got %q", ghost)
+	}
+	ac.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight}) // moves the cursor, nothing to complete
+	if ac.Text != "o" || ac.getGhost() != "ne" {
+		t.Errorf("expected ghost ne back at the end, got %q %q", ac.Text, ac.getGhost())
+	}
+
+	ac.MultiLine = true
+	typeText("t")
+	if ghost := ac.getGhost(); ghost != "" {
+		t.Errorf("no ghost in a multiline entry, got %q", ghost)
+	}
+}
+
+func TestAutoComplete_Inline_Both(t *testing.T) {
+	ac, w := newTestAutoComplete(t)
+	ac.Mode = CompletionBoth
+	ac.Provider = NewPrefixProvider(StringOptions("one", "two", "three"))
+	w.Canvas().Focus(ac)
+
+	test.Type(ac, "t")
+	waitFor(t, ac, func() bool { return ac.ListVisible() && !ac.results().loading && ac.getGhost() == "wo" })
+	typeKey(ac, fyne.KeyDown)
+	if ghost := ac.getGhost(); ghost != "hree" {
+		t.Errorf("ghost should follow the selection, got %q", ghost)
+	}
+	typeKey(ac, fyne.KeyTab)
+	if ac.Text != "three" || ac.ListVisible() {
+		t.Errorf("tab should complete the selected option, got %q", ac.Text)
+	}
+
+	ac.SetText("")
+	test.Type(ac, "o")
+	waitFor(t, ac, func() bool { return ac.ListVisible() && !ac.results().loading && ac.getGhost() == "ne" })
+	ac.ListHide()
+	if ghost := ac.getGhost(); ghost != "" {
+		t.Errorf("ListHide should clear the ghost, got %q", ghost)
+	}
+}
@@ -0,0 +1,153 @@
+package autocomplete
+
+import (
+	"context"
+	"sync"
+
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// NewAutoCompleteWithData creates a new single line AutoComplete whose text is bound to text,
+// suggesting the values of options (see Bind and BindOptions).
+func NewAutoCompleteWithData(text binding.String, options binding.StringList) *AutoComplete {
+	ac := NewAutoComplete(1)
+	ac.Bind(text)
+	ac.BindOptions(options)
+	return ac
+}
+
+// Bind connects the text to data, just like widget.Entry.Bind: changes of data are displayed
+// (without querying the Provider, like SetText) and the text typed, set or completed
+// from the list is set into data.
+// The data changes are applied serially with the UI events, like the Provider results.
+func (ac *AutoComplete) Bind(data binding.String) {
+	if ac.text != nil {
+		ac.Unbind()
+	}
+	ac.text = data
+	ac.Validator = func(string) error {
+		return ac.dataError()
+	}
+	ac.strictValidator = false
+	ac.setupStrictValidator()
+
+	ac.Entry.OnChanged = func(s string) {
+		ac.writeData() // nothing to write if updated from data
+		ac.onChanged(s)
+		ac.Validate()
+	}
+	ac.textListener = binding.NewDataListener(func() {
+		ac.ui.Lock()
+		defer ac.ui.Unlock()
+		v, err := data.Get()
+		ac.setDataError(err)
+		if err == nil && v != ac.Text {
+			ac.SetText(v)
+		}
+		ac.Validate()
+	})
+	data.AddListener(ac.textListener)
+}
+
+// Unbind disconnects the text from its data source, the current text is kept.
+func (ac *AutoComplete) Unbind() {
+	if ac.text != nil {
+		ac.text.RemoveListener(ac.textListener)
+	}
+	ac.Entry.OnChanged = ac.onChanged
+	ac.Validator = nil
+	ac.text = nil
+	ac.textListener = nil
+	ac.setDataError(nil)
+	ac.strictValidator = false
+	ac.setupStrictValidator()
+}
+
+// writeData sets the text into the bound data, if any.
+// Needed when the text is changed without calling Entry.SetText (completion).
+func (ac *AutoComplete) writeData() {
+	if ac.text == nil {
+		return
+	}
+	if v, err := ac.text.Get(); err == nil && v == ac.Text {
+		ac.setDataError(nil)
+		return
+	}
+	ac.setDataError(ac.text.Set(ac.Text))
+}
+
+// dataError returns the last error getting or setting the bound text, reported by the Validator.
+func (ac *AutoComplete) dataError() error {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	return ac.textError
+}
+
+func (ac *AutoComplete) setDataError(err error) {
+	ac.mu.Lock()
+	ac.textError = err
+	ac.mu.Unlock()
+}
+
+// BindOptions sets a Provider suggesting the values of data that fuzzy match the text
+// (see NewFuzzyProvider). When data changes, the suggestions displayed are updated.
+func (ac *AutoComplete) BindOptions(data binding.StringList) {
+	ac.UnbindOptions()
+
+	provider := &dataProvider{}
+	if values, err := data.Get(); err == nil {
+		provider.set(values) // don't wait for the listener, called asynchronously
+	}
+	ac.Provider = provider
+	ac.optionsData = data
+	ac.optionsListener = binding.NewDataListener(func() {
+		values, err := data.Get()
+		if err != nil {
+			return
+		}
+		provider.set(values)
+
+		// like the Provider results, serially with the UI events
+		ac.ui.Lock()
+		defer ac.ui.Unlock()
+		if res := ac.results(); ac.ListVisible() && !res.history && res.query != "" {
+			ctx := ac.startLookup() // the list is kept until the results are applied
+			go ac.suggest(ctx, res.query, 0)
+		}
+	})
+	data.AddListener(ac.optionsListener)
+}
+
+// UnbindOptions disconnects the options from their data source,
+// the last values are still suggested.
+func (ac *AutoComplete) UnbindOptions() {
+	if ac.optionsData == nil {
+		return
+	}
+	ac.optionsData.RemoveListener(ac.optionsListener)
+	ac.optionsData = nil
+	ac.optionsListener = nil
+}
+
+// dataProvider suggests the values of a bound StringList, updated by its listener.
+type dataProvider struct {
+	mu       sync.Mutex
+	provider SuggestionProvider
+}
+
+func (p *dataProvider) set(values []string) {
+	provider := NewFuzzyProvider(StringOptions(values...))
+	p.mu.Lock()
+	p.provider = provider
+	p.mu.Unlock()
+}
+
+func (p *dataProvider) Suggest(ctx context.Context, query string) []Option {
+	p.mu.Lock()
+	provider := p.provider
+	p.mu.Unlock()
+	if provider == nil {
+		return nil
+	}
+	return provider.Suggest(ctx, query)
+}
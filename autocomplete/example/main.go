@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"fyne.io/fyne/v2/app"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/brianvoe/gofakeit/v6"
+
+	"github.com/matwachich/fyne-examples/autocomplete"
+)
+
+/* This example show an AutoComplete Entry, just like the one in fyne-x
+   but improved (simpler code, multiligne items...)
+*/
+
+func main() {
+	a := app.NewWithID("com.github.matwachich.fyne-examples.autocomplete") // an ID is needed by Preferences
+	w := a.NewWindow("AutoComplete Entry")
+
+	// options carry the real object behind each suggestion
+	var persons []autocomplete.Option
+	for i := 0; i < 100; i++ {
+		p := &person{
+			FirstName: gofakeit.FirstName(),
+			LastName:  gofakeit.LastName(),
+			Email:     gofakeit.Email(),
+		}
+		persons = append(persons, autocomplete.Option{
+			Value:  p,
+			Label:  p.FirstName + " " + p.LastName,
+			Detail: p.Email,
+			Icon:   theme.AccountIcon(),
+		})
+	}
+	sort.Slice(persons, func(i, j int) bool { return persons[i].Label < persons[j].Label })
+
+	var companies []string
+	for i := 0; i < 30; i++ {
+		companies = append(companies, gofakeit.Company())
+	}
+	sort.Strings(companies)
+
+	selected := widget.NewLabel("")
+
+	ac := autocomplete.NewAutoComplete(1)
+
+	// the provider is called by the AutoComplete each time the text changes
+	// write your own to do what you want (DB calls, Results filtering...)
+	// it runs in its own goroutine, so slow lookups don't freeze the UI
+	// here, suggestions are grouped in two sections
+	ac.Provider = autocomplete.NewSectionsProvider(
+		autocomplete.Section{Title: "Contacts", Provider: autocomplete.NewFuzzyProvider(persons)}, // or NewPrefixProvider
+		autocomplete.Section{Title: "Companies", Provider: autocomplete.NewFuzzyProvider(autocomplete.StringOptions(companies...))},
+	)
+	ac.Debounce = 150 * time.Millisecond                                        // wait for the user to stop typing
+	ac.Mode = autocomplete.CompletionBoth                                       // best match is also displayed inline, Tab to accept
+	ac.History = autocomplete.NewHistory(a.Preferences(), "search.history", 10) // focus it empty to see the history
+	ac.OnCompleted = func(opt autocomplete.Option) string {
+		switch v := opt.Value.(type) {
+		case *person:
+			selected.SetText("Selected person: " + v.FirstName + " " + v.LastName + " <" + v.Email + ">")
+		case string:
+			selected.SetText("Selected company: " + v)
+		}
+		return opt.Label
+	}
+
+	// multiple values: each completed person becomes a token
+	recipients := autocomplete.NewAutoComplete(1)
+	recipients.Provider = autocomplete.NewFuzzyProvider(persons)
+	recipients.MultiValue = true
+	recipients.SetPlaceHolder("Recipients...")
+
+	// multiline: @ mentions a person, # a company
+	notes := autocomplete.NewAutoComplete(5)
+	mentions := autocomplete.NewFuzzyProvider(persons)
+	tags := autocomplete.NewFuzzyProvider(autocomplete.StringOptions(companies...))
+	notes.Provider = autocomplete.SuggestionProviderFunc(func(ctx context.Context, query string) []autocomplete.Option {
+		if trigger, _ := autocomplete.TriggerFromContext(ctx); trigger == '#' {
+			return tags.Suggest(ctx, query)
+		}
+		return mentions.Suggest(ctx, query)
+	})
+	notes.Triggers = []rune{'@', '#'}
+	notes.SetPlaceHolder("Notes... (@ to mention someone, # for a company)")
+
+	w.SetContent(container.NewBorder(container.NewVBox(ac, recipients, notes), nil, nil, nil, selected))
+	w.ShowAndRun()
+}
+
+type person struct {
+	FirstName, LastName string
+	Email               string
+}
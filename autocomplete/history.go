@@ -0,0 +1,113 @@
+package autocomplete
+
+import (
+	"encoding/json"
+
+	"fyne.io/fyne/v2"
+)
+
+// DefaultHistorySize is the number of values kept by a History created with max <= 0.
+const DefaultHistorySize = 10
+
+// History is a list of most recently used values, persisted in fyne.Preferences.
+//
+// Set it as the History of one or more AutoComplete to record the values they
+// complete or submit, and suggest them when the AutoComplete is focused empty.
+type History struct {
+	prefs  fyne.Preferences
+	key    string
+	max    int
+	values []string // most recent first
+}
+
+// NewHistory creates a History keeping at most max values, stored in prefs under key
+// (usually fyne.CurrentApp().Preferences()). Previously stored values are loaded.
+// If prefs is nil, the History will not be persisted. If max <= 0, DefaultHistorySize is used.
+func NewHistory(prefs fyne.Preferences, key string, max int) *History {
+	if max <= 0 {
+		max = DefaultHistorySize
+	}
+	h := &History{prefs: prefs, key: key, max: max}
+	if prefs != nil {
+		_ = json.Unmarshal([]byte(prefs.String(key)), &h.values)
+	}
+	if len(h.values) > max {
+		h.values = h.values[:max]
+	}
+	return h
+}
+
+// Values returns the values, most recent first.
+func (h *History) Values() []string {
+	return append([]string(nil), h.values...)
+}
+
+// Add puts s at the top of the History. Empty strings are ignored.
+func (h *History) Add(s string) {
+	if s == "" {
+		return
+	}
+	h.remove(s)
+	h.values = append([]string{s}, h.values...)
+	if len(h.values) > h.max {
+		h.values = h.values[:h.max]
+	}
+	h.save()
+}
+
+// Remove deletes s from the History.
+func (h *History) Remove(s string) {
+	if h.remove(s) {
+		h.save()
+	}
+}
+
+// Clear deletes all the values.
+func (h *History) Clear() {
+	h.values = nil
+	h.save()
+}
+
+func (h *History) remove(s string) bool {
+	for i := range h.values {
+		if h.values[i] == s {
+			h.values = append(h.values[:i], h.values[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (h *History) save() {
+	if h.prefs == nil {
+		return
+	}
+	data, _ := json.Marshal(h.values)
+	h.prefs.SetString(h.key, string(data))
+}
+
+// ---
+
+// showHistory displays the History values in the list, if any.
+func (ac *AutoComplete) showHistory() bool {
+	if ac.History == nil || ac.Mode == CompletionInline || len(ac.Triggers) > 0 || len(ac.History.values) == 0 {
+		return false
+	}
+	ac.cancelSuggest()
+	ac.mu.Lock()
+	ac.Options = StringOptions(ac.History.Values()...)
+	ac.page = page{}
+	ac.query = ""
+	ac.showingHistory = true
+	ac.mu.Unlock()
+	ac.ListShow()
+	return true
+}
+
+// removeFromHistory deletes the History value displayed at id, and updates the list.
+func (ac *AutoComplete) removeFromHistory(id int) {
+	ac.History.Remove(ac.options()[id].Label)
+	if !ac.showHistory() {
+		ac.ListHide()
+	}
+}
@@ -0,0 +1,85 @@
+package autocomplete
+
+import "strings"
+
+// CompletionMode defines how an AutoComplete displays its suggestions.
+type CompletionMode int
+
+const (
+	// CompletionPopup displays the suggestions in a list under the Entry (default).
+	CompletionPopup CompletionMode = iota
+	// CompletionInline displays the rest of the best suggestion as grey text after
+	// the typed text, shell style. Tab or Right arrow accepts it.
+	CompletionInline
+	// CompletionBoth displays the popup list, and the selected suggestion inline.
+	CompletionBoth
+)
+
+// ghostOption returns the option to complete inline: the one selected in the list,
+// or the first selectable one.
+func (ac *AutoComplete) ghostOption() (Option, bool) {
+	visible := ac.ListVisible()
+	options, id := ac.options(), ac.getSelected()
+	if !visible || !selectable(options, id) {
+		id = ac.nextSelectable(-1, 1)
+	}
+	if id < 0 || id >= len(options) {
+		return Option{}, false
+	}
+	return options[id], true
+}
+
+// updateGhost records the text and the cursor position, and computes the inline completion.
+// It reads the Entry, unlike refreshGhost that can be called by the lookup goroutines.
+func (ac *AutoComplete) updateGhost() {
+	ac.mu.Lock()
+	ac.typed = ac.Text
+	ac.typedEnd = ac.CursorColumn == len([]rune(ac.Text))
+	ac.mu.Unlock()
+	ac.refreshGhost()
+}
+
+// refreshGhost computes the inline completion: the rest of the ghostOption Label,
+// if it starts with the typed text and the cursor is at the end of the text (see updateGhost).
+func (ac *AutoComplete) refreshGhost() {
+	ac.mu.Lock()
+	text, end := ac.typed, ac.typedEnd
+	ac.mu.Unlock()
+
+	ghost := ""
+	opt, ok := ac.ghostOption()
+	typed := []rune(text)
+	if ac.Mode != CompletionPopup && ok && text != "" && !ac.MultiLine && len(ac.Triggers) == 0 && end {
+		label := []rune(opt.Label)
+		if len(label) > len(typed) && strings.EqualFold(string(label[:len(typed)]), text) {
+			ghost = string(label[len(typed):])
+		}
+	}
+	ac.setGhost(ghost)
+}
+
+func (ac *AutoComplete) setGhost(ghost string) {
+	ac.mu.Lock()
+	changed := ghost != ac.ghost
+	ac.ghost = ghost
+	ac.mu.Unlock()
+	if changed {
+		ac.Refresh()
+	}
+}
+
+func (ac *AutoComplete) getGhost() string {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	return ac.ghost
+}
+
+// acceptGhost completes the inline completion, if any.
+func (ac *AutoComplete) acceptGhost() bool {
+	if ac.getGhost() == "" {
+		return false
+	}
+	opt, _ := ac.ghostOption()
+	ac.setTextFromList(opt)
+	return true
+}
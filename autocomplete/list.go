@@ -0,0 +1,271 @@
+package autocomplete
+
+import (
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+type autoCompleteList struct {
+	widget.List
+	parent *AutoComplete
+
+	mu       sync.Mutex                    // serializes the items updates, the list can be refreshed by a lookup goroutine
+	template *autoCompleteListItem         // reused to measure rows
+	heights  map[widget.ListItemID]float32 // row heights given to SetItemHeight
+}
+
+func newAutoCompleteList(parent *AutoComplete) *autoCompleteList {
+	list := &autoCompleteList{parent: parent}
+	list.ExtendBaseWidget(list)
+	list.List.Length = func() int {
+		res := parent.results()
+		if res.loading || res.page.paged {
+			return len(res.options) + 1 // loading or footer row
+		}
+		return len(res.options)
+	}
+	list.List.CreateItem = func() fyne.CanvasObject {
+		var item *autoCompleteListItem
+		if parent.CustomCreate != nil {
+			item = newAutoCompleteListItem(parent, parent.CustomCreate())
+		} else {
+			item = newAutoCompleteListItem(parent, newOptionItem())
+		}
+		return item
+	}
+	list.List.UpdateItem = func(id widget.ListItemID, co fyne.CanvasObject) {
+		if id == len(parent.options()) {
+			parent.loadMore() // footer scrolled into view
+		}
+		list.mu.Lock()
+		list.updateItem(id, co.(*autoCompleteListItem))
+		height := co.MinSize().Height
+		list.mu.Unlock()
+		list.setRowHeight(id, height)
+	}
+	list.List.OnSelected = func(id widget.ListItemID) {
+		parent.setSelected(id)
+		parent.refreshGhost()
+		if parent.nextSelectable(id, 1) <= id && parent.loadMore() {
+			list.Refresh() // last option selected, displays the loading row
+		}
+	}
+	list.List.OnUnselected = func(_ widget.ListItemID) {
+		parent.setSelected(-1)
+	}
+	return list
+}
+
+// Refresh is also called when the canvas is resized (the popup refreshes its content),
+// in that case the popup is laid out again.
+func (list *autoCompleteList) Refresh() {
+	parent := list.parent
+	if cnv := fyne.CurrentApp().Driver().CanvasForObject(parent); cnv != nil && parent.ListVisible() {
+		parent.mu.Lock()
+		resized := cnv.Size() != parent.popupCanvasSize
+		parent.mu.Unlock()
+		if resized {
+			parent.popupRelayout()
+		}
+	}
+	list.List.Refresh()
+}
+
+// updateItem displays the row id in item, list.mu must be held.
+func (list *autoCompleteList) updateItem(id widget.ListItemID, item *autoCompleteListItem) {
+	parent := list.parent
+	res := parent.results()
+	item.setID(id)
+	if id >= len(res.options) && res.loading {
+		item.setStatus(loadingText, fyne.TextStyle{Italic: true})
+	} else if id >= len(res.options) {
+		item.setStatus(res.page.footerText(), fyne.TextStyle{Italic: true})
+	} else if res.options[id].Header {
+		item.setStatus(res.options[id].Label, fyne.TextStyle{Bold: true})
+	} else {
+		item.setStatus("", fyne.TextStyle{})
+		if parent.CustomUpdate != nil {
+			parent.CustomUpdate(id, item.co)
+		} else {
+			item.co.(*optionItem).update(res.options[id], res.query)
+		}
+	}
+	item.Refresh()
+}
+
+// measureRow returns the size of row id, measured with the template item.
+// The row height is not given to the list here: SetItemHeight refreshes the whole list
+// (and creates an item to measure its template), it is done by UpdateItem once the row is displayed.
+func (list *autoCompleteList) measureRow(id widget.ListItemID) fyne.Size {
+	list.mu.Lock()
+	defer list.mu.Unlock()
+	if list.template == nil {
+		list.template = list.CreateItem().(*autoCompleteListItem)
+	}
+	list.updateItem(id, list.template)
+	return list.template.MinSize()
+}
+
+// setRowHeight calls SetItemHeight, that refreshes the whole list, only if the height changed.
+func (list *autoCompleteList) setRowHeight(id widget.ListItemID, height float32) {
+	list.mu.Lock()
+	if list.heights == nil {
+		list.heights = make(map[widget.ListItemID]float32)
+	}
+	h, ok := list.heights[id]
+	list.heights[id] = height
+	list.mu.Unlock()
+	if !ok || h != height {
+		list.SetItemHeight(id, height)
+	}
+}
+
+// selectRow selects the row id, if >= 0, and scrolls to it.
+func (list *autoCompleteList) selectRow(id widget.ListItemID) {
+	if id < 0 {
+		return
+	}
+	list.Select(id)
+	list.ScrollTo(id)
+}
+
+// pageRows returns the number of rows PageUp and PageDown move by: the rows fitting in the list,
+// assuming they are as high as the selected one.
+func (list *autoCompleteList) pageRows() int {
+	id := list.parent.getSelected()
+	if id < 0 {
+		id = 0
+	}
+	row := list.measureRow(id).Height + theme.Padding()
+	if n := int(list.Size().Height / row); n > 1 {
+		return n
+	}
+	return 1
+}
+
+func (list *autoCompleteList) AcceptsTab() bool {
+	return true
+}
+
+func (list *autoCompleteList) FocusGained() {}
+func (list *autoCompleteList) FocusLost()   { list.parent.setGhost("") }
+
+func (list *autoCompleteList) TypedRune(r rune) {
+	list.parent.TypedRune(r)
+}
+func (list *autoCompleteList) TypedKey(k *fyne.KeyEvent) {
+	parent := list.parent
+	defer parent.uiEvent()()
+	res, selected := parent.results(), parent.getSelected()
+	switch k.Name {
+	case fyne.KeyDown:
+		if id := parent.nextSelectable(selected, 1); id > selected || !res.page.more {
+			list.selectRow(id)
+		}
+	case fyne.KeyUp:
+		list.selectRow(parent.nextSelectable(selected, -1))
+	case fyne.KeyPageDown:
+		list.selectRow(parent.selectableFrom(selected+list.pageRows(), 1))
+	case fyne.KeyPageUp:
+		list.selectRow(parent.selectableFrom(selected-list.pageRows(), -1))
+	case fyne.KeyHome:
+		list.selectRow(parent.selectableFrom(0, 1))
+	case fyne.KeyEnd:
+		list.selectRow(parent.selectableFrom(len(res.options)-1, -1))
+	case fyne.KeyReturn, fyne.KeyEnter:
+		if selectable(res.options, selected) {
+			parent.setTextFromList(res.options[selected])
+		} else {
+			parent.ListHide()
+			parent.Entry.TypedKey(k)
+		}
+	case fyne.KeyTab:
+		if !parent.acceptGhost() {
+			parent.ListHide()
+		}
+	case fyne.KeyEscape:
+		parent.ListHide()
+	case fyne.KeyDelete:
+		if res.history && selectable(res.options, selected) {
+			parent.removeFromHistory(selected)
+		} else {
+			parent.TypedKey(k)
+		}
+	default:
+		parent.TypedKey(k)
+	}
+}
+
+func (list *autoCompleteList) TypedShortcut(s fyne.Shortcut) { list.parent.TypedShortcut(s) }
+
+// ---
+
+const loadingText = "Loading…"
+
+type autoCompleteListItem struct {
+	widget.BaseWidget
+	parent *AutoComplete
+	co     fyne.CanvasObject
+	status *widget.Label // replaces co on non-selectable rows (headers, loading...)
+
+	mu sync.Mutex // protects id, the list can be refreshed by a lookup goroutine
+	id widget.ListItemID
+}
+
+func newAutoCompleteListItem(parent *AutoComplete, co fyne.CanvasObject) *autoCompleteListItem {
+	item := &autoCompleteListItem{parent: parent, id: -1, co: co}
+	item.status = widget.NewLabel("")
+	item.status.Hide()
+	item.ExtendBaseWidget(item)
+	return item
+}
+
+func (item *autoCompleteListItem) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(container.NewMax(item.co, item.status))
+}
+
+func (item *autoCompleteListItem) getID() widget.ListItemID {
+	item.mu.Lock()
+	defer item.mu.Unlock()
+	return item.id
+}
+
+func (item *autoCompleteListItem) setID(id widget.ListItemID) {
+	item.mu.Lock()
+	item.id = id
+	item.mu.Unlock()
+}
+
+// setStatus displays text instead of the item content, or the content back if text is empty.
+func (item *autoCompleteListItem) setStatus(text string, style fyne.TextStyle) {
+	item.status.Text = text
+	item.status.TextStyle = style
+	if text == "" {
+		item.status.Hide()
+		item.co.Show()
+	} else {
+		item.co.Hide()
+		item.status.Show()
+	}
+}
+
+func (item *autoCompleteListItem) Tapped(_ *fyne.PointEvent) {
+	defer item.parent.uiEvent()()
+	if id, options := item.getID(), item.parent.options(); selectable(options, id) {
+		item.parent.setTextFromList(options[id])
+	}
+}
+
+func (item *autoCompleteListItem) MouseIn(_ *desktop.MouseEvent) {
+	defer item.parent.uiEvent()()
+	if id := item.getID(); item.parent.selectable(id) {
+		item.parent.list.Select(id)
+	}
+}
+func (item *autoCompleteListItem) MouseMoved(_ *desktop.MouseEvent) {}
+func (item *autoCompleteListItem) MouseOut()                        {}
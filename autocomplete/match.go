@@ -0,0 +1,186 @@
+package autocomplete
+
+import (
+	"unicode"
+
+	"fyne.io/fyne/v2/widget"
+)
+
+// Match is a run of runes [Start, End) of a suggestion that matched the query.
+type Match struct {
+	Start, End int
+}
+
+// scoring of FuzzyMatch
+const (
+	scoreRune        = 1  // each matched rune
+	scoreConsecutive = 4  // matched rune following a matched rune
+	scoreWordStart   = 8  // matched rune at the start of a word
+	scoreFirstRune   = 12 // matched rune at the start of s
+	maxGapPenalty    = 8  // runes skipped before the first match
+)
+
+// match tiers of FuzzyMatch, each one adding tierBonus (per query rune) to the score
+const (
+	tierScattered = iota
+	tierSubstring
+	tierWordStart // substring starting a word
+	tierPrefix
+)
+
+// tierBonus is higher than the score of any match of a lower tier, for each query rune.
+const tierBonus = scoreRune + scoreConsecutive + scoreFirstRune + maxGapPenalty
+
+// FuzzyMatch reports whether all the runes of query appear in s, in order (case insensitive).
+//
+// It prefers, in this order: a prefix, a substring starting a word ("smith" in "John Smith"),
+// any substring, and finally scattered runes ("jsm" in "John Smith").
+// The returned score is higher for better matches and can be used to rank suggestions,
+// matches are the runs of s that matched (in runes).
+func FuzzyMatch(query, s string) (score int, matches []Match, ok bool) {
+	q := []rune(query)
+	r := []rune(s)
+	if len(q) == 0 {
+		return 0, nil, true
+	}
+	for i := range q {
+		q[i] = unicode.ToLower(q[i])
+	}
+	lower := make([]rune, len(r))
+	for i := range r {
+		lower[i] = unicode.ToLower(r[i])
+	}
+
+	// contiguous substring, starting a word if possible
+	start := -1
+	for i := 0; i+len(q) <= len(lower); i++ {
+		if runesEqual(lower[i:i+len(q)], q) {
+			if isWordStart(r, i) {
+				start = i
+				break
+			}
+			if start < 0 {
+				start = i
+			}
+		}
+	}
+	if start >= 0 {
+		positions := make([]int, len(q))
+		for i := range positions {
+			positions[i] = start + i
+		}
+		tier := tierSubstring
+		if start == 0 {
+			tier = tierPrefix
+		} else if isWordStart(r, start) {
+			tier = tierWordStart
+		}
+		return tier*tierBonus*len(q) + fuzzyScore(r, positions), []Match{{start, start + len(q)}}, true
+	}
+
+	// scattered runes: prefer word starts, fallback to leftmost match
+	positions := scatteredPositions(r, lower, q, true)
+	if positions == nil {
+		positions = scatteredPositions(r, lower, q, false)
+	}
+	if positions == nil {
+		return 0, nil, false
+	}
+	for _, p := range positions {
+		if n := len(matches); n > 0 && matches[n-1].End == p {
+			matches[n-1].End++
+		} else {
+			matches = append(matches, Match{p, p + 1})
+		}
+	}
+	return tierScattered*tierBonus*len(q) + fuzzyScore(r, positions), matches, true
+}
+
+// scatteredPositions returns the positions in lower of each rune of q, or nil if there is no match.
+// If wordStarts, a non consecutive rune is matched at the next word start if there is one.
+func scatteredPositions(r, lower, q []rune, wordStarts bool) []int {
+	positions := make([]int, 0, len(q))
+	for i := 0; i < len(lower) && len(positions) < len(q); i++ {
+		j := len(positions)
+		if lower[i] != q[j] {
+			continue
+		}
+		if wordStarts && j > 0 && positions[j-1] != i-1 && !isWordStart(r, i) {
+			for k := i + 1; k < len(lower); k++ {
+				if lower[k] == q[j] && isWordStart(r, k) {
+					i = k
+					break
+				}
+			}
+		}
+		positions = append(positions, i)
+	}
+	if len(positions) < len(q) {
+		return nil
+	}
+	return positions
+}
+
+func fuzzyScore(r []rune, positions []int) (score int) {
+	for i, p := range positions {
+		score += scoreRune
+		if i > 0 && positions[i-1] == p-1 {
+			score += scoreConsecutive
+		}
+		if p == 0 {
+			score += scoreFirstRune
+		} else if isWordStart(r, p) {
+			score += scoreWordStart
+		}
+	}
+	gap := positions[0]
+	if gap > maxGapPenalty {
+		gap = maxGapPenalty
+	}
+	return score - gap
+}
+
+func isWordStart(r []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev, cur := r[i-1], r[i]
+	return !unicode.IsLetter(prev) && !unicode.IsDigit(prev) ||
+		unicode.IsLower(prev) && unicode.IsUpper(cur) // camelCase
+}
+
+func runesEqual(a, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// highlightSegments returns the RichText segments displaying s with the matches in bold.
+func highlightSegments(s string, matches []Match) []widget.RichTextSegment {
+	r := []rune(s)
+	var segs []widget.RichTextSegment
+	add := func(from, to int, strong bool) {
+		if from >= to {
+			return
+		}
+		style := widget.RichTextStyleInline
+		if strong {
+			style = widget.RichTextStyleStrong
+		}
+		segs = append(segs, &widget.TextSegment{Text: string(r[from:to]), Style: style})
+	}
+	pos := 0
+	for _, m := range matches {
+		add(pos, m.Start, false)
+		add(m.Start, m.End, true)
+		pos = m.End
+	}
+	add(pos, len(r), false)
+	if len(segs) == 0 {
+		segs = append(segs, &widget.TextSegment{Style: widget.RichTextStyleInline})
+	}
+	return segs
+}
@@ -0,0 +1,85 @@
+package autocomplete
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Option is a suggestion displayed in the AutoComplete list.
+type Option struct {
+	Value  any           // the object behind the suggestion (DB record...), not used by the AutoComplete
+	Label  string        // displayed, matched against the query, and completed by default
+	Detail string        // secondary text displayed under the Label, optional
+	Icon   fyne.Resource // displayed before the Label, optional
+	Header bool          // if true, the Label is displayed as a section header that can't be selected
+}
+
+// HeaderOption returns a section header Option.
+func HeaderOption(title string) Option {
+	return Option{Label: title, Header: true}
+}
+
+// StringOptions returns an Option for each string, with Label and Value set to it.
+func StringOptions(labels ...string) []Option {
+	ret := make([]Option, len(labels))
+	for i := range labels {
+		ret[i] = Option{Value: labels[i], Label: labels[i]}
+	}
+	return ret
+}
+
+// ---
+
+// optionItem is the default list item: icon, highlighted label and detail.
+type optionItem struct {
+	widget.BaseWidget
+	icon   *widget.Icon
+	label  *widget.RichText
+	detail *widget.RichText
+}
+
+func newOptionItem() *optionItem {
+	item := &optionItem{
+		icon:   widget.NewIcon(nil),
+		label:  widget.NewRichText(),
+		detail: widget.NewRichText(),
+	}
+	item.ExtendBaseWidget(item)
+	return item
+}
+
+func (item *optionItem) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(container.NewBorder(nil, nil, item.icon, nil,
+		container.NewVBox(item.label, item.detail),
+	))
+}
+
+// update displays opt, with the parts matching query in bold.
+func (item *optionItem) update(opt Option, query string) {
+	_, matches, _ := FuzzyMatch(query, opt.Label)
+	item.label.Segments = highlightSegments(opt.Label, matches)
+
+	if opt.Detail == "" {
+		item.detail.Hide()
+	} else {
+		item.detail.Segments = []widget.RichTextSegment{&widget.TextSegment{
+			Text: opt.Detail,
+			Style: widget.RichTextStyle{
+				Inline:    true,
+				ColorName: theme.ColorNamePlaceHolder,
+				SizeName:  theme.SizeNameCaptionText,
+			},
+		}}
+		item.detail.Show()
+	}
+
+	if opt.Icon == nil {
+		item.icon.Hide()
+	} else {
+		item.icon.SetResource(opt.Icon)
+		item.icon.Show()
+	}
+	item.Refresh()
+}
@@ -0,0 +1,72 @@
+package autocomplete
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// page is the paging state of the Options returned by a PagedSuggestionProvider.
+type page struct {
+	paged  bool // Options come from a PagedSuggestionProvider, a footer row is displayed
+	loaded int  // options returned so far, offset of the next page
+	total  int  // -1 if unknown
+	more   bool // there is a next page
+}
+
+func (ac *AutoComplete) pageSize() int {
+	if ac.PageSize > 0 {
+		return ac.PageSize
+	}
+	return DefaultPageSize
+}
+
+// setPage updates the paging state with the options returned from offset, mu must be held.
+func (ac *AutoComplete) setPage(paged bool, offset, count, total int) {
+	ac.page = page{paged: paged, loaded: offset + count, total: total}
+	if total >= 0 {
+		ac.page.more = ac.page.loaded < total
+	} else {
+		ac.page.more = count >= ac.pageSize()
+	}
+}
+
+// loadMore requests the next page of suggestions, if any. The footer row displays the
+// loading row until it is received.
+func (ac *AutoComplete) loadMore() bool {
+	res := ac.results()
+	if !res.page.more || res.loading {
+		return false
+	}
+	ctx := ac.startLookup()
+	go ac.suggest(ctx, res.query, res.page.loaded)
+	return true
+}
+
+// showNextPage displays the options appended to the list, keeping the selection.
+func (ac *AutoComplete) showNextPage() {
+	if !ac.ListVisible() {
+		return
+	}
+	ac.popupRelayout()
+	ac.list.Refresh()
+}
+
+// footerText is displayed in the last row of paged suggestions.
+func (p page) footerText() string {
+	if p.total < 0 {
+		return "Showing " + formatCount(p.loaded)
+	}
+	return fmt.Sprintf("Showing %s of %s", formatCount(p.loaded), formatCount(p.total))
+}
+
+// formatCount formats n with thousands separators (1,204).
+func formatCount(n int) string {
+	if n < 0 {
+		return "-" + formatCount(-n)
+	}
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
@@ -0,0 +1,134 @@
+package autocomplete
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// SuggestionProvider computes the suggestions displayed by an AutoComplete.
+//
+// Suggest is called outside of the UI goroutine, so it can block (DB calls, network...).
+// ctx is cancelled as soon as the user types again: long running lookups should
+// abort when it is done, their result will be discarded anyway.
+type SuggestionProvider interface {
+	// Suggest returns the options matching query, best match first.
+	Suggest(ctx context.Context, query string) []Option
+}
+
+// SuggestionProviderFunc is an adapter to use an ordinary function as a SuggestionProvider.
+type SuggestionProviderFunc func(ctx context.Context, query string) []Option
+
+// Suggest calls f(ctx, query).
+func (f SuggestionProviderFunc) Suggest(ctx context.Context, query string) []Option {
+	return f(ctx, query)
+}
+
+// PagedSuggestionProvider is a SuggestionProvider that can return its suggestions by pages,
+// for large backends. The AutoComplete requests the first page on each text change,
+// and the next one when the user reaches the bottom of the list.
+type PagedSuggestionProvider interface {
+	SuggestionProvider
+	// SuggestPage returns at most limit options matching query, starting at offset,
+	// and the total count of matching options (-1 if unknown).
+	SuggestPage(ctx context.Context, query string, offset, limit int) (options []Option, total int)
+}
+
+// DefaultPageSize is the number of options requested per page if AutoComplete.PageSize is not set.
+const DefaultPageSize = 50
+
+// PagedSuggestionProviderFunc is an adapter to use an ordinary function as a PagedSuggestionProvider.
+type PagedSuggestionProviderFunc func(ctx context.Context, query string, offset, limit int) ([]Option, int)
+
+// Suggest returns the first page of f.
+func (f PagedSuggestionProviderFunc) Suggest(ctx context.Context, query string) []Option {
+	options, _ := f(ctx, query, 0, DefaultPageSize)
+	return options
+}
+
+// SuggestPage calls f(ctx, query, offset, limit).
+func (f PagedSuggestionProviderFunc) SuggestPage(ctx context.Context, query string, offset, limit int) ([]Option, int) {
+	return f(ctx, query, offset, limit)
+}
+
+// NewPrefixProvider returns a SuggestionProvider that suggests the options whose Label
+// starts with the query (case insensitive), in their original order.
+func NewPrefixProvider(options []Option) SuggestionProvider {
+	p := &prefixProvider{options: options}
+	for i := 0; i < len(options); i++ {
+		p.lower = append(p.lower, strings.ToLower(options[i].Label))
+	}
+	return p
+}
+
+type prefixProvider struct {
+	options []Option
+	lower   []string // lowercase labels, computed once
+}
+
+func (p *prefixProvider) Suggest(_ context.Context, query string) (ret []Option) {
+	query = strings.ToLower(query)
+	for i := 0; i < len(p.lower); i++ {
+		if strings.HasPrefix(p.lower[i], query) {
+			ret = append(ret, p.options[i])
+		}
+	}
+	return
+}
+
+// Section is a group of suggestions, displayed under its Title.
+type Section struct {
+	Title    string
+	Provider SuggestionProvider
+}
+
+// NewSectionsProvider returns a SuggestionProvider that queries the Provider of each section
+// and returns their suggestions grouped under a header Option. Empty sections are skipped.
+func NewSectionsProvider(sections ...Section) SuggestionProvider {
+	return SuggestionProviderFunc(func(ctx context.Context, query string) (ret []Option) {
+		for _, section := range sections {
+			options := section.Provider.Suggest(ctx, query)
+			if ctx.Err() != nil {
+				return nil
+			}
+			if len(options) > 0 {
+				ret = append(ret, HeaderOption(section.Title))
+				ret = append(ret, options...)
+			}
+		}
+		return
+	})
+}
+
+// NewFuzzyProvider returns a SuggestionProvider that suggests the options whose Label
+// matches the query according to FuzzyMatch, best score first.
+func NewFuzzyProvider(options []Option) SuggestionProvider {
+	return &fuzzyProvider{options: options}
+}
+
+type fuzzyProvider struct {
+	options []Option
+}
+
+func (p *fuzzyProvider) Suggest(ctx context.Context, query string) []Option {
+	type scored struct {
+		option Option
+		score  int
+	}
+	var found []scored
+	for i := 0; i < len(p.options); i++ {
+		if i%1024 == 0 && ctx.Err() != nil {
+			return nil
+		}
+		if score, _, ok := FuzzyMatch(query, p.options[i].Label); ok {
+			found = append(found, scored{p.options[i], score})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].score > found[j].score })
+
+	ret := make([]Option, len(found))
+	for i := range found {
+		ret[i] = found[i].option
+	}
+	return ret
+}
@@ -0,0 +1,93 @@
+package autocomplete
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+)
+
+// autoCompleteRenderer extends the Entry renderer with the inline completion
+// (ghost text after the typed text) and the tokens row, laid out at the bottom of the Entry.
+type autoCompleteRenderer struct {
+	fyne.WidgetRenderer // Entry renderer
+
+	ac     *AutoComplete
+	ghost  *canvas.Text
+	scroll *container.Scroll
+}
+
+func newAutoCompleteRenderer(ac *AutoComplete, entry fyne.WidgetRenderer) *autoCompleteRenderer {
+	ac.tokens = container.NewHBox()
+	r := &autoCompleteRenderer{
+		WidgetRenderer: entry,
+		ac:             ac,
+		ghost:          canvas.NewText("", theme.PlaceHolderColor()),
+		scroll:         container.NewHScroll(ac.tokens),
+	}
+	ac.updateTokens()
+	return r
+}
+
+func (r *autoCompleteRenderer) Layout(size fyne.Size) {
+	r.WidgetRenderer.Layout(size)
+	r.layoutGhost(size)
+	r.layoutTokens(size)
+}
+
+// layoutGhost places the ghost text right after the typed text (single line only).
+func (r *autoCompleteRenderer) layoutGhost(size fyne.Size) {
+	r.ac.mu.Lock()
+	text, typed := r.ac.ghost, r.ac.typed
+	r.ac.mu.Unlock()
+	r.ghost.Text = text
+	r.ghost.Color = theme.PlaceHolderColor()
+	r.ghost.TextStyle = r.ac.TextStyle
+	r.ghost.TextSize = theme.TextSize()
+
+	ghost := r.ghost.MinSize()
+	x := theme.InnerPadding() + fyne.MeasureText(typed, r.ghost.TextSize, r.ghost.TextStyle).Width
+	if text == "" || r.ac.MultiLine || x+ghost.Width > size.Width-theme.InnerPadding() {
+		r.ghost.Hide() // would overflow: the Entry is scrolled
+		return
+	}
+	r.ghost.Move(fyne.NewPos(x, theme.InnerPadding()))
+	r.ghost.Resize(ghost)
+	r.ghost.Show()
+	r.ghost.Refresh()
+}
+
+func (r *autoCompleteRenderer) layoutTokens(size fyne.Size) {
+	if !r.tokensVisible() {
+		r.scroll.Hide()
+		return
+	}
+	r.scroll.Show()
+
+	h := r.ac.tokens.MinSize().Height
+	r.scroll.Resize(fyne.NewSize(size.Width-2*theme.InputBorderSize()-theme.InnerPadding(), h))
+	r.scroll.Move(fyne.NewPos(theme.InputBorderSize()+theme.InnerPadding()/2, size.Height-h-theme.InputBorderSize()))
+}
+
+func (r *autoCompleteRenderer) MinSize() fyne.Size {
+	min := r.WidgetRenderer.MinSize()
+	if r.tokensVisible() {
+		min.Height += r.ac.tokens.MinSize().Height
+	}
+	return min
+}
+
+func (r *autoCompleteRenderer) Objects() []fyne.CanvasObject {
+	objs := r.WidgetRenderer.Objects()
+	return append(objs[:len(objs):len(objs)], r.ghost, r.scroll)
+}
+
+func (r *autoCompleteRenderer) Refresh() {
+	r.WidgetRenderer.Refresh()
+	r.layoutGhost(r.ac.Size())
+	r.layoutTokens(r.ac.Size())
+}
+
+func (r *autoCompleteRenderer) tokensVisible() bool {
+	return r.ac.MultiValue && len(r.ac.values) > 0
+}
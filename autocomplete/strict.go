@@ -0,0 +1,79 @@
+package autocomplete
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrNotAnOption is the validation error of a Strict AutoComplete whose text is not one of the options.
+var ErrNotAnOption = errors.New("not one of the suggested values")
+
+// Selected returns the option chosen for the current text: the one completed from the list,
+// or the one whose Label is the text. It returns false if the text matches no option.
+func (ac *AutoComplete) Selected() (Option, bool) {
+	if ac.completed != nil && ac.completedText == ac.Text {
+		return *ac.completed, true
+	}
+	res := ac.results()
+	if res.history {
+		return Option{}, false // history values are not options
+	}
+	for _, opt := range res.options {
+		if !opt.Header && opt.Label == ac.Text {
+			return opt, true
+		}
+	}
+	return Option{}, false
+}
+
+// Validate validates the text (see widget.Entry.Validate). In Strict mode,
+// a text that is not empty and not one of the options is invalid (ErrNotAnOption).
+//
+// Implements: fyne.Validatable
+func (ac *AutoComplete) Validate() error {
+	ac.setupStrictValidator()
+	return ac.Entry.Validate()
+}
+
+// setupStrictValidator wraps the Validator of a Strict AutoComplete to check the text is
+// one of the options. Not done in NewAutoComplete because an Entry with a Validator
+// displays a validation icon.
+func (ac *AutoComplete) setupStrictValidator() {
+	if !ac.Strict || ac.strictValidator {
+		return
+	}
+	ac.strictValidator = true
+	validator := ac.Validator
+	ac.Validator = func(s string) error {
+		if ac.Strict && s != "" {
+			if _, ok := ac.Selected(); !ok {
+				return ErrNotAnOption
+			}
+		}
+		if validator != nil {
+			return validator(s)
+		}
+		return nil
+	}
+}
+
+// strictFocusLost completes the option whose Label is the text with a different case (if it
+// is the only one), or flags the text as invalid.
+func (ac *AutoComplete) strictFocusLost() {
+	if !ac.Strict || ac.Text == "" {
+		return
+	}
+	if _, ok := ac.Selected(); !ok && !ac.results().history {
+		var found []Option
+		for _, opt := range ac.options() {
+			if !opt.Header && strings.EqualFold(opt.Label, ac.Text) {
+				found = append(found, opt)
+			}
+		}
+		if len(found) == 1 {
+			ac.setTextFromList(found[0])
+			return
+		}
+	}
+	ac.Validate()
+}
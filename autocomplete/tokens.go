@@ -0,0 +1,62 @@
+package autocomplete
+
+import (
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Values returns the tokens of a MultiValue AutoComplete.
+func (ac *AutoComplete) Values() []string {
+	return append([]string(nil), ac.values...)
+}
+
+// SetValues replaces the tokens of a MultiValue AutoComplete.
+// OnValuesChanged is not called.
+func (ac *AutoComplete) SetValues(values []string) {
+	ac.values = append(ac.values[:0], values...)
+	ac.refreshTokens()
+}
+
+// addValue adds a token, unless it's already there.
+func (ac *AutoComplete) addValue(s string) {
+	for _, v := range ac.values {
+		if v == s {
+			return
+		}
+	}
+	ac.values = append(ac.values, s)
+	ac.valuesChanged()
+}
+
+func (ac *AutoComplete) removeValue(i int) {
+	ac.values = append(ac.values[:i], ac.values[i+1:]...)
+	ac.valuesChanged()
+}
+
+func (ac *AutoComplete) valuesChanged() {
+	ac.refreshTokens()
+	if ac.OnValuesChanged != nil {
+		ac.OnValuesChanged(ac.Values())
+	}
+}
+
+func (ac *AutoComplete) refreshTokens() {
+	if ac.tokens == nil {
+		return // not rendered yet
+	}
+	ac.updateTokens()
+	ac.tokens.Refresh()
+	ac.Refresh()
+}
+
+// updateTokens rebuilds the tokens buttons. Tapping a token removes it.
+func (ac *AutoComplete) updateTokens() {
+	ac.tokens.Objects = ac.tokens.Objects[:0]
+	for i := range ac.values {
+		i := i
+		btn := widget.NewButtonWithIcon(ac.values[i], theme.CancelIcon(), func() { ac.removeValue(i) })
+		btn.IconPlacement = widget.ButtonIconTrailingText
+		btn.Importance = widget.LowImportance
+		ac.tokens.Objects = append(ac.tokens.Objects, btn)
+	}
+}
@@ -0,0 +1,139 @@
+package autocomplete
+
+import (
+	"context"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+// token is the word under the cursor being completed, in Triggers mode.
+type token struct {
+	trigger    rune
+	start, end int // runes of the text, trigger included
+
+	row    int    // cursor row (wrapped), to anchor the popup
+	before string // text of the cursor row before the word
+}
+
+type triggerKey struct{}
+
+// TriggerFromContext returns the trigger rune of the word being completed, from the context
+// given to SuggestionProvider.Suggest. It returns false if the AutoComplete has no Triggers.
+func TriggerFromContext(ctx context.Context) (rune, bool) {
+	r, ok := ctx.Value(triggerKey{}).(rune)
+	return r, ok
+}
+
+// updateToken finds the word under the cursor starting with one of the Triggers,
+// the query becomes this word (without its trigger).
+//
+// The Entry doesn't give the cursor position in the text (only its row and column,
+// wrapped rows included), so it is deduced from the change: it is after the inserted text.
+func (ac *AutoComplete) updateToken(s string) {
+	prev, text := []rune(ac.prevText), []rune(s)
+	ac.prevText = s
+	ac.setToken(nil)
+
+	n := len(prev)
+	if len(text) < n {
+		n = len(text)
+	}
+	prefix := 0
+	for prefix < n && prev[prefix] == text[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < n-prefix && prev[len(prev)-1-suffix] == text[len(text)-1-suffix] {
+		suffix++
+	}
+	pos := len(text) - suffix
+
+	start, end := pos, pos
+	for start > 0 && !unicode.IsSpace(text[start-1]) {
+		start--
+	}
+	for end < len(text) && !unicode.IsSpace(text[end]) {
+		end++
+	}
+	if start == end || !ac.isTrigger(text[start]) {
+		return
+	}
+
+	rowStart := pos - ac.CursorColumn
+	if rowStart < 0 || rowStart > start {
+		rowStart = start
+	}
+	ac.mu.Lock()
+	ac.tok = &token{trigger: text[start], start: start, end: end, row: ac.CursorRow, before: string(text[rowStart:start])}
+	ac.query = string(text[start+1 : end])
+	ac.mu.Unlock()
+}
+
+// token returns the word being completed, nil if none.
+func (ac *AutoComplete) token() *token {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	return ac.tok
+}
+
+func (ac *AutoComplete) setToken(tok *token) {
+	ac.mu.Lock()
+	ac.tok = tok
+	ac.mu.Unlock()
+}
+
+func (ac *AutoComplete) isTrigger(r rune) bool {
+	for _, t := range ac.Triggers {
+		if r == t {
+			return true
+		}
+	}
+	return false
+}
+
+// replaceToken replaces the word being completed by its trigger and s, followed by a space,
+// and moves the cursor after it.
+func (ac *AutoComplete) replaceToken(s string) {
+	tok := ac.token()
+	ac.setToken(nil)
+	text := []rune(ac.Text)
+	before := string(text[:tok.start]) + string(tok.trigger) + s
+	after := text[tok.end:]
+	if len(after) == 0 || !unicode.IsSpace(after[0]) {
+		before += " "
+	}
+
+	onChanged := ac.Entry.OnChanged
+	ac.Entry.OnChanged = nil // the bound data is written by setTextFromList
+
+	// setting the text truncates the cursor position: set it at the end of the text
+	// before the cursor, the wrapped rows are computed by the Entry
+	ac.CursorRow, ac.CursorColumn = len(text), len(text)
+	ac.Entry.SetText(before)
+	ac.Entry.SetText(before + string(after))
+
+	ac.Entry.OnChanged = onChanged
+	ac.prevText = ac.Text
+}
+
+// popupAnchor returns the absolute position and the size of the area the popup is displayed
+// below or above: the AutoComplete, or the word being completed in Triggers mode.
+func (ac *AutoComplete) popupAnchor() (fyne.Position, fyne.Size) {
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(ac)
+	size := ac.Size()
+	tok := ac.token()
+	if tok == nil {
+		return pos, size
+	}
+
+	line := fyne.MeasureText("M", theme.TextSize(), ac.TextStyle).Height
+	x := theme.InnerPadding() + fyne.MeasureText(tok.before, theme.TextSize(), ac.TextStyle).Width
+	y := theme.InnerPadding() + line*float32(tok.row)
+	// the Entry may be scrolled, keep the anchor inside
+	x = fyne.Min(x, size.Width-theme.InnerPadding())
+	y = fyne.Min(y, size.Height-theme.InnerPadding()-line)
+
+	return pos.Add(fyne.NewPos(x, y)), fyne.NewSize(size.Width-x, line)
+}
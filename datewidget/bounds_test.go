@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestDateEntry_Bounds(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+
+	d := NewDateEntry()
+	d.MinDate = time.Date(2024, 1, 10, 12, 0, 0, 0, time.Local) // compared by day
+	d.MaxDate = time.Date(2024, 1, 20, 0, 0, 0, 0, time.Local)
+	var changed []time.Time
+	d.OnChanged = func(tm time.Time) { changed = append(changed, tm) }
+
+	// typed dates out of bounds are not valid
+	d.SetString("09012024")
+	if !d.GetTime().IsZero() || d.GetString() != "" || !errors.Is(d.Validate(), ErrOutOfRange) {
+		t.Errorf("expected %q to be refused", d.Text)
+	}
+	d.SetString("10012024")
+	if d.GetTime().IsZero() || d.Validate() != nil {
+		t.Errorf("expected %q to be accepted", d.Text)
+	}
+
+	// increments are clamped
+	d.CursorColumn = 1
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	if d.Text != "10/01/2024" {
+		t.Errorf("expected the date to be clamped to the min, got %q", d.Text)
+	}
+	d.CursorColumn = 4
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyUp})
+	if d.Text != "20/01/2024" {
+		t.Errorf("expected the date to be clamped to the max, got %q", d.Text)
+	}
+
+	// typing an invalid date after a valid one emits a zero time
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyEscape})
+	changed = nil
+	for _, r := range "21012024" {
+		d.TypedRune(r)
+	}
+	if len(changed) != 0 {
+		t.Errorf("expected no valid date, got %v", changed)
+	}
+}
+
+func TestDateEntry_DateAllowed(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+
+	d := NewDateEntry()
+	d.DateAllowed = func(tm time.Time) bool {
+		return tm.Weekday() != time.Saturday && tm.Weekday() != time.Sunday
+	}
+	d.SetString("05012024") // friday
+
+	// the weekend is skipped
+	d.CursorColumn = 1
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyUp})
+	if d.Text != "08/01/2024" {
+		t.Errorf("expected monday, got %q", d.Text)
+	}
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	if d.Text != "05/01/2024" {
+		t.Errorf("expected friday, got %q", d.Text)
+	}
+
+	// and disabled in the calendar
+	c := newCalendar(d.GetTime(), d.dateAllowed)
+	if !c.days[5].Disabled() || c.days[4].Disabled() {
+		t.Error("expected the saturday to be disabled, not the friday")
+	}
+	picked := false
+	c.OnPicked = func(time.Time) { picked = true }
+	c.pick(c.dayAt(5))
+	if picked {
+		t.Error("a disabled day should not be picked")
+	}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+var calendarIcon = theme.NewThemedResource(fyne.NewStaticResource("calendar.svg", []byte(
+	`<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24">`+
+		`<path d="M20 3h-1V1h-2v2H7V1H5v2H4c-1.1 0-2 .9-2 2v16c0 1.1.9 2 2 2h16c1.1 0 2-.9 2-2V5c0-1.1-.9-2-2-2zm0 18H4V8h16v13z"/>`+
+		`</svg>`)))
+
+// calendar is a month grid to pick a day with the mouse or the keyboard
+// (arrows move the day, page up and down the month, enter picks it, escape cancels).
+type calendar struct {
+	widget.BaseWidget
+
+	OnPicked func(time.Time)
+	OnCancel func()
+
+	allowed func(time.Time) bool // if not nil, the days it refuses are disabled
+
+	cursor   time.Time // highlighted day
+	today    time.Time
+	from, to time.Time // highlighted span, if not zero
+
+	title *widget.Label
+	days  [6 * 7]*widget.Button
+}
+
+func newCalendar(tm time.Time, allowed func(time.Time) bool) *calendar {
+	c := &calendar{allowed: allowed, title: widget.NewLabelWithStyle("", fyne.TextAlignCenter, fyne.TextStyle{Bold: true})}
+	c.ExtendBaseWidget(c)
+	for i := range c.days {
+		i := i
+		c.days[i] = widget.NewButton("", func() { c.pick(c.dayAt(i)) })
+	}
+	c.setCursor(tm)
+	return c
+}
+
+func (c *calendar) CreateRenderer() fyne.WidgetRenderer {
+	header := container.NewBorder(nil, nil,
+		container.NewHBox(
+			widget.NewButtonWithIcon("", theme.MediaFastRewindIcon(), func() { c.setCursor(addMonths(c.cursor, -12)) }),
+			widget.NewButtonWithIcon("", theme.NavigateBackIcon(), func() { c.setCursor(addMonths(c.cursor, -1)) }),
+		),
+		container.NewHBox(
+			widget.NewButtonWithIcon("", theme.NavigateNextIcon(), func() { c.setCursor(addMonths(c.cursor, 1)) }),
+			widget.NewButtonWithIcon("", theme.MediaFastForwardIcon(), func() { c.setCursor(addMonths(c.cursor, 12)) }),
+		),
+		c.title,
+	)
+
+	grid := container.NewGridWithColumns(7)
+	for i := 0; i < 7; i++ {
+		day := time.Weekday((i + 1) % 7) // weeks start on monday
+		grid.Add(widget.NewLabelWithStyle(day.String()[:2], fyne.TextAlignCenter, fyne.TextStyle{Italic: true}))
+	}
+	for _, b := range c.days {
+		grid.Add(b)
+	}
+	return widget.NewSimpleRenderer(container.NewBorder(header, nil, nil, nil, grid))
+}
+
+// setCursor highlights the day of tm, and displays its month.
+func (c *calendar) setCursor(tm time.Time) {
+	if tm.IsZero() {
+		tm = time.Now()
+	}
+	y, m, d := tm.Date()
+	c.cursor = time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	y, m, d = time.Now().Date()
+	c.today = time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+
+	c.title.SetText(c.cursor.Month().String() + " " + strconv.Itoa(c.cursor.Year()))
+	for i, b := range c.days {
+		day := c.dayAt(i)
+		b.SetText(strconv.Itoa(day.Day()))
+		switch {
+		case day.Equal(c.cursor), day.Equal(c.from), day.Equal(c.to):
+			b.Importance = widget.HighImportance
+		case !c.from.IsZero() && !c.to.IsZero() && day.After(c.from) && day.Before(c.to):
+			b.Importance = widget.MediumImportance
+		case day.Equal(c.today):
+			b.Importance = widget.MediumImportance
+		default:
+			b.Importance = widget.LowImportance
+		}
+		if day.Month() != c.cursor.Month() || c.allowed != nil && !c.allowed(day) {
+			b.Disable()
+		} else {
+			b.Enable()
+		}
+		b.Refresh()
+	}
+}
+
+// setSpan highlights the days from from to to, included.
+func (c *calendar) setSpan(from, to time.Time) {
+	c.from, c.to = from, to
+	if !from.IsZero() {
+		c.from = dateOf(from)
+	}
+	if !to.IsZero() {
+		c.to = dateOf(to)
+	}
+	c.setCursor(c.cursor)
+}
+
+// dayAt returns the day displayed in the cell i of the grid.
+func (c *calendar) dayAt(i int) time.Time {
+	first := time.Date(c.cursor.Year(), c.cursor.Month(), 1, 0, 0, 0, 0, time.Local)
+	offset := (int(first.Weekday()) + 6) % 7 // days of the previous month, weeks start on monday
+	return first.AddDate(0, 0, i-offset)
+}
+
+func (c *calendar) pick(tm time.Time) {
+	if c.allowed != nil && !c.allowed(tm) {
+		return
+	}
+	if c.OnPicked != nil {
+		c.OnPicked(tm)
+	}
+}
+
+func (c *calendar) FocusGained()     {}
+func (c *calendar) FocusLost()       {}
+func (c *calendar) TypedRune(_ rune) {}
+
+func (c *calendar) TypedKey(k *fyne.KeyEvent) {
+	switch k.Name {
+	case fyne.KeyLeft:
+		c.setCursor(c.cursor.AddDate(0, 0, -1))
+	case fyne.KeyRight:
+		c.setCursor(c.cursor.AddDate(0, 0, 1))
+	case fyne.KeyUp:
+		c.setCursor(c.cursor.AddDate(0, 0, -7))
+	case fyne.KeyDown:
+		c.setCursor(c.cursor.AddDate(0, 0, 7))
+	case fyne.KeyPageUp:
+		c.setCursor(addMonths(c.cursor, -1))
+	case fyne.KeyPageDown:
+		c.setCursor(addMonths(c.cursor, 1))
+	case fyne.KeyHome:
+		c.setCursor(c.today)
+	case fyne.KeyReturn, fyne.KeyEnter, fyne.KeySpace:
+		c.pick(c.cursor)
+	case fyne.KeyEscape:
+		if c.OnCancel != nil {
+			c.OnCancel()
+		}
+	}
+}
+
+// addMonths adds n months to tm, the day is kept in the resulting month (January 31 + 1 month is February 28 or 29).
+func addMonths(tm time.Time, n int) time.Time {
+	first := time.Date(tm.Year(), tm.Month()+time.Month(n), 1, 0, 0, 0, 0, tm.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	day := tm.Day()
+	if day > last {
+		day = last
+	}
+	return time.Date(first.Year(), first.Month(), day, tm.Hour(), tm.Minute(), tm.Second(), tm.Nanosecond(), tm.Location())
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestAddMonths(t *testing.T) {
+	for _, tt := range []struct {
+		from time.Time
+		n    int
+		want time.Time
+	}{
+		{time.Date(2024, 1, 31, 0, 0, 0, 0, time.Local), 1, time.Date(2024, 2, 29, 0, 0, 0, 0, time.Local)},
+		{time.Date(2023, 3, 31, 0, 0, 0, 0, time.Local), -1, time.Date(2023, 2, 28, 0, 0, 0, 0, time.Local)},
+		{time.Date(2024, 2, 29, 0, 0, 0, 0, time.Local), 12, time.Date(2025, 2, 28, 0, 0, 0, 0, time.Local)},
+		{time.Date(2024, 12, 15, 0, 0, 0, 0, time.Local), 1, time.Date(2025, 1, 15, 0, 0, 0, 0, time.Local)},
+	} {
+		if got := addMonths(tt.from, tt.n); !got.Equal(tt.want) {
+			t.Errorf("%v + %d months: expected %v, got %v", tt.from, tt.n, tt.want, got)
+		}
+	}
+}
+
+func TestCalendar_dayAt(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+
+	c := newCalendar(time.Date(2024, 2, 14, 0, 0, 0, 0, time.Local), nil) // february 1st 2024 is a thursday
+	if first := c.dayAt(0); !first.Equal(time.Date(2024, 1, 29, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("grid should start on monday january 29, got %v", first)
+	}
+	if c.days[3].Disabled() || c.days[3].Text != "1" {
+		t.Errorf("february 1st should be the 4th cell, got %q", c.days[3].Text)
+	}
+	if !c.days[0].Disabled() {
+		t.Error("days of the previous month should be disabled")
+	}
+}
+
+func TestDateEntry_ShowCalendar(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+
+	d := NewDateEntry()
+	w := test.NewWindow(container.NewVBox(d))
+	w.Resize(fyne.NewSize(400, 400))
+	t.Cleanup(w.Close)
+
+	var changed, submitted time.Time
+	d.OnChanged = func(tm time.Time) { changed = tm }
+	d.OnSubmitted = func(tm time.Time) { submitted = tm }
+	d.SetString("28022023")
+
+	d.ShowCalendar()
+	cal, ok := w.Canvas().Focused().(*calendar)
+	if !ok {
+		t.Fatal("calendar should be focused")
+	}
+	cal.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+	cal.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+
+	want := time.Date(2023, 3, 1, 0, 0, 0, 0, time.Local)
+	if d.Text != "01/03/2023" || !changed.Equal(want) || !submitted.Equal(want) {
+		t.Errorf("expected 01/03/2023 to be picked, got %q (changed %v, submitted %v)", d.Text, changed, submitted)
+	}
+	if d.calendar != nil || w.Canvas().Focused() != d {
+		t.Error("calendar should be hidden, and the entry focused")
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// DateRangePreset is a named range of days (Range gets the current day, at midnight).
+type DateRangePreset struct {
+	Name  string
+	Range func(today time.Time) (from, to time.Time)
+}
+
+// DefaultDateRangePresets are the presets of a new DateRangeEntry. Weeks start on monday.
+var DefaultDateRangePresets = []DateRangePreset{
+	{"Today", func(today time.Time) (time.Time, time.Time) {
+		return today, today
+	}},
+	{"This week", func(today time.Time) (time.Time, time.Time) {
+		monday := weekStart(today)
+		return monday, monday.AddDate(0, 0, 6)
+	}},
+	{"Last week", func(today time.Time) (time.Time, time.Time) {
+		monday := weekStart(today).AddDate(0, 0, -7)
+		return monday, monday.AddDate(0, 0, 6)
+	}},
+	{"Last 30 days", func(today time.Time) (time.Time, time.Time) {
+		return today.AddDate(0, 0, -29), today
+	}},
+	{"This month", func(today time.Time) (time.Time, time.Time) {
+		first := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.Local)
+		return first, first.AddDate(0, 1, -1)
+	}},
+	{"Last month", func(today time.Time) (time.Time, time.Time) {
+		first := time.Date(today.Year(), today.Month()-1, 1, 0, 0, 0, 0, time.Local)
+		return first, first.AddDate(0, 1, -1)
+	}},
+	{"Year to date", func(today time.Time) (time.Time, time.Time) {
+		return time.Date(today.Year(), time.January, 1, 0, 0, 0, 0, time.Local), today
+	}},
+}
+
+// weekStart returns the monday of the week of day.
+func weekStart(day time.Time) time.Time {
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// DateRangeEntry is a pair of DateEntry to select a range of days, the start being before
+// (or equal to) the end: an end before the start is not valid.
+//
+// The range can be picked in a calendar (click the first day, then the last one), or chosen
+// in a list of presets.
+type DateRangeEntry struct {
+	widget.BaseWidget
+
+	OnChanged func(from, to time.Time) // Called when the range changes, a zero time is not valid
+
+	Presets []DateRangePreset // DefaultDateRangePresets by default
+
+	from, to *DateEntry
+	last     [2]time.Time // last range given to OnChanged
+	calendar *widget.PopUp
+}
+
+// NewDateRangeEntry creates a new DateRangeEntry.
+func NewDateRangeEntry() *DateRangeEntry {
+	r := &DateRangeEntry{
+		Presets: DefaultDateRangePresets,
+		from:    NewDateEntry(),
+		to:      NewDateEntry(),
+	}
+	r.ExtendBaseWidget(r)
+	r.from.ActionItem = nil // the calendar is shared
+	r.to.ActionItem = nil
+	r.from.OnChanged = func(time.Time) { r.updateBounds(); r.changed() }
+	r.to.OnChanged = func(time.Time) { r.changed() }
+	return r
+}
+
+func (r *DateRangeEntry) CreateRenderer() fyne.WidgetRenderer {
+	var presets *widget.Button
+	presets = widget.NewButtonWithIcon("", theme.MenuDropDownIcon(), func() { r.showPresets(presets) })
+	return widget.NewSimpleRenderer(container.NewHBox(
+		r.from,
+		widget.NewLabel("–"),
+		r.to,
+		widget.NewButtonWithIcon("", calendarIcon, r.ShowCalendar),
+		presets,
+	))
+}
+
+// SetLayout changes the date format of both entries, see DateEntry.SetLayout.
+func (r *DateRangeEntry) SetLayout(layout string) error {
+	if err := r.from.SetLayout(layout); err != nil {
+		return err
+	}
+	return r.to.SetLayout(layout)
+}
+
+// SetRange sets the displayed range, a zero time sets an empty entry.
+// OnChanged is not called.
+func (r *DateRangeEntry) SetRange(from, to time.Time) {
+	r.setRange(from, to)
+	r.last = [2]time.Time{r.from.GetTime(), r.to.GetTime()}
+}
+
+// GetRange returns the entered range, a zero time if the entry is not valid.
+func (r *DateRangeEntry) GetRange() (from, to time.Time) {
+	return r.from.GetTime(), r.to.GetTime()
+}
+
+// ShowCalendar displays a calendar under the entry, with the range highlighted.
+// A new range is picked by clicking its first day, then its last one.
+func (r *DateRangeEntry) ShowCalendar() {
+	cnv := fyne.CurrentApp().Driver().CanvasForObject(r)
+	if cnv == nil {
+		return
+	}
+	from, to := r.GetRange()
+	cursor := from
+	if cursor.IsZero() {
+		cursor = to
+	}
+	cal := newCalendar(cursor, nil)
+	cal.setSpan(from, to)
+	var first time.Time
+	cal.OnPicked = func(tm time.Time) {
+		if first.IsZero() {
+			first = tm
+			cal.setSpan(tm, tm)
+			return
+		}
+		from, to := first, tm
+		if to.Before(from) {
+			from, to = to, from
+		}
+		r.HideCalendar()
+		r.setRange(from, to)
+		r.changed()
+	}
+	cal.OnCancel = r.HideCalendar
+	r.HideCalendar()
+	r.calendar = widget.NewPopUp(cal, cnv)
+	r.calendar.ShowAtPosition(fyne.CurrentApp().Driver().AbsolutePositionForObject(r).Add(fyne.NewPos(0, r.Size().Height)))
+	cnv.Focus(cal)
+}
+
+// HideCalendar hides the calendar, if displayed.
+func (r *DateRangeEntry) HideCalendar() {
+	if r.calendar != nil {
+		r.calendar.Hide()
+		r.calendar = nil
+	}
+}
+
+// ------------------------------------------------------------------------------------------------
+
+func (r *DateRangeEntry) showPresets(button fyne.CanvasObject) {
+	cnv := fyne.CurrentApp().Driver().CanvasForObject(button)
+	if cnv == nil {
+		return
+	}
+	items := make([]*fyne.MenuItem, len(r.Presets))
+	for i, p := range r.Presets {
+		p := p
+		items[i] = fyne.NewMenuItem(p.Name, func() { r.applyPreset(p) })
+	}
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(button).Add(fyne.NewPos(0, button.Size().Height))
+	widget.ShowPopUpMenuAtPosition(fyne.NewMenu("", items...), cnv, pos)
+}
+
+func (r *DateRangeEntry) applyPreset(p DateRangePreset) {
+	r.setRange(p.Range(dateOf(time.Now())))
+	r.changed()
+}
+
+func (r *DateRangeEntry) setRange(from, to time.Time) {
+	r.to.MinDate = time.Time{}
+	r.from.SetTime(from)
+	r.to.SetTime(to)
+	r.updateBounds()
+}
+
+// updateBounds makes the start the min date of the end.
+func (r *DateRangeEntry) updateBounds() {
+	r.to.MinDate = r.from.GetTime()
+	r.to.Validate()
+}
+
+// changed calls OnChanged if the range is not the last one given.
+func (r *DateRangeEntry) changed() {
+	from, to := r.GetRange()
+	if from.Equal(r.last[0]) && to.Equal(r.last[1]) {
+		return
+	}
+	r.last = [2]time.Time{from, to}
+	if r.OnChanged != nil {
+		r.OnChanged(from, to)
+	}
+}
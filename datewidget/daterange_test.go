@@ -0,0 +1,86 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+)
+
+func TestDefaultDateRangePresets(t *testing.T) {
+	today := time.Date(2024, time.March, 13, 0, 0, 0, 0, time.Local) // a wednesday
+	day := func(m time.Month, d int) time.Time { return time.Date(2024, m, d, 0, 0, 0, 0, time.Local) }
+	want := map[string][2]time.Time{
+		"Today":        {today, today},
+		"This week":    {day(3, 11), day(3, 17)},
+		"Last week":    {day(3, 4), day(3, 10)},
+		"Last 30 days": {day(2, 13), today},
+		"This month":   {day(3, 1), day(3, 31)},
+		"Last month":   {day(2, 1), day(2, 29)},
+		"Year to date": {day(1, 1), today},
+	}
+	for _, p := range DefaultDateRangePresets {
+		from, to := p.Range(today)
+		if w := want[p.Name]; !from.Equal(w[0]) || !to.Equal(w[1]) {
+			t.Errorf("%s: expected %v - %v, got %v - %v", p.Name, w[0], w[1], from, to)
+		}
+	}
+}
+
+func TestDateRangeEntry(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+
+	r := NewDateRangeEntry()
+	w := test.NewWindow(r)
+	t.Cleanup(w.Close)
+	var changed [][2]time.Time
+	r.OnChanged = func(from, to time.Time) { changed = append(changed, [2]time.Time{from, to}) }
+
+	for _, c := range "10032024" {
+		r.from.TypedRune(c)
+	}
+	for _, c := range "09032024" {
+		r.to.TypedRune(c)
+	}
+	if from, to := r.GetRange(); from.IsZero() || !to.IsZero() || r.to.Validate() == nil {
+		t.Errorf("expected an end before the start to be invalid, got %v - %v", from, to)
+	}
+
+	// typing a valid end
+	changed = nil
+	r.to.CursorColumn = 0
+	r.to.TypedRune('1')
+	from, to := r.GetRange()
+	if len(changed) != 1 || !changed[0][0].Equal(from) || !changed[0][1].Equal(to) || !to.Equal(time.Date(2024, 3, 19, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("unexpected changes %v", changed)
+	}
+
+	// moving the start after the end invalidates the end
+	changed = nil
+	r.from.CursorColumn = 0
+	r.from.TypedRune('2')
+	if len(changed) != 1 || !changed[0][1].IsZero() {
+		t.Errorf("expected an invalid end, got %v", changed)
+	}
+
+	// picking in the calendar, the last day first
+	changed = nil
+	r.ShowCalendar()
+	cal := r.calendar.Content.(*calendar)
+	cal.pick(time.Date(2024, 4, 5, 0, 0, 0, 0, time.Local))
+	if r.calendar == nil || len(changed) != 0 {
+		t.Fatal("expected the calendar to wait for the second day")
+	}
+	cal.pick(time.Date(2024, 4, 1, 0, 0, 0, 0, time.Local))
+	if r.calendar != nil || r.from.Text != "01/04/2024" || r.to.Text != "05/04/2024" || len(changed) != 1 {
+		t.Errorf("unexpected range %q - %q, changes %v", r.from.Text, r.to.Text, changed)
+	}
+
+	// SetRange does not call OnChanged
+	changed = nil
+	r.SetRange(time.Time{}, time.Time{})
+	if r.from.Text != "__/__/____" || len(changed) != 0 {
+		t.Errorf("unexpected SetRange result %q, changes %v", r.from.Text, changed)
+	}
+}
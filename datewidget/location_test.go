@@ -0,0 +1,82 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+)
+
+func TestDateTimeEntry_Location(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip(err)
+	}
+	e := NewDateTimeEntry()
+	e.Location = paris
+
+	// stored in UTC, displayed in Paris
+	stored := time.Date(2024, time.July, 14, 20, 0, 0, 0, time.UTC)
+	e.SetTime(stored)
+	if e.Text != "14/07/2024 22:00" || !e.GetTime().Equal(stored) {
+		t.Errorf("unexpected value %q, %v", e.Text, e.GetTime())
+	}
+
+	// the UTC toggle keeps the instant
+	var changed time.Time
+	e.OnChanged = func(tm time.Time) { changed = tm }
+	e.SetUTC(true)
+	if e.Text != "14/07/2024 20:00" || !e.GetTime().Equal(stored) || !e.IsUTC() {
+		t.Errorf("unexpected UTC value %q, %v", e.Text, e.GetTime())
+	}
+	e.SetString("15/07/2024 08:30")
+	if want := time.Date(2024, time.July, 15, 8, 30, 0, 0, time.UTC); !e.GetTime().Equal(want) || !changed.Equal(want) {
+		t.Errorf("expected %v, got %v (changed %v)", want, e.GetTime(), changed)
+	}
+	changed = time.Time{}
+	e.SetUTC(false)
+	if e.Text != "15/07/2024 10:30" || !changed.IsZero() {
+		t.Errorf("unexpected value %q, changed %v", e.Text, changed)
+	}
+}
+
+func TestDateTimeEntry_DST(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip(err)
+	}
+	e := NewDateTimeEntry()
+	e.Location = paris
+
+	// 02:30 happens twice on October 27, 2024: 00:30 UTC (CEST) and 01:30 UTC (CET)
+	for _, stored := range []time.Time{
+		time.Date(2024, time.October, 27, 0, 30, 0, 0, time.UTC),
+		time.Date(2024, time.October, 27, 1, 30, 0, 0, time.UTC),
+	} {
+		e.SetTime(stored)
+		if e.Text != "27/10/2024 02:30" || !e.GetTime().Equal(stored) {
+			t.Errorf("%v: unexpected value %q, %v", stored, e.Text, e.GetTime().UTC())
+		}
+	}
+
+	// 02:30 doesn't exist on March 31, 2024: the clocks go from 02:00 to 03:00
+	e.Text = "31/03/2024 02:30"
+	if err := e.Validate(); !errors.Is(err, ErrImpossibleTime) || !e.GetTime().IsZero() {
+		t.Errorf("skipped time should be impossible, got %v, %v", err, e.GetTime())
+	}
+
+	// a date entry keeps the day, whatever the location of tm
+	d := NewDateEntry()
+	d.Location = paris
+	d.SetTime(time.Date(2024, time.March, 15, 23, 30, 0, 0, time.UTC)) // the 16th in Paris
+	if d.Text != "15/03/2024" || !d.GetTime().Equal(time.Date(2024, time.March, 15, 0, 0, 0, 0, paris)) {
+		t.Errorf("unexpected date %q, %v", d.Text, d.GetTime())
+	}
+}
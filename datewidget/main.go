@@ -2,15 +2,12 @@ package main
 
 import (
 	"fmt"
-	"strconv"
 	"time"
 
-	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/layout"
-	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
@@ -20,291 +17,100 @@ func main() {
 
 	date := NewDateEntry()
 	date.OnSubmitted = func(tm time.Time) {
-		dialog.ShowInformation("Date Entry", tm.Format("Entered date: 02/01/2006"), w)
+		dialog.ShowInformation("Date Entry", "Entered date: "+tm.Format("Monday, January 2, 2006"), w)
 	}
 
-	w.SetContent(container.NewBorder(date, nil, nil, nil, layout.NewSpacer()))
+	format := widget.NewSelect([]string{LayoutDMY, LayoutMDY, LayoutISO}, func(layout string) {
+		date.SetLayout(layout)
+	})
+	format.SetSelected(LayoutDMY)
 
-	w.ShowAndRun()
-}
-
-// DateEntry is a widget.Entry that only accept a date input.
-// Date format is 02/01/2006 (dd/mm/yyyy).
-type DateEntry struct {
-	widget.Entry
-
-	OnChanged   func(time.Time) // Called when the data changes
-	OnSubmitted func(time.Time) // Called when Enter is pressed in the input
-
-	valid bool
-}
-
-// NewDateEntry creates a new DateEntry.
-func NewDateEntry() *DateEntry {
-	d := &DateEntry{}
-	d.ExtendBaseWidget(d)
-	d.Text = "__/__/____"
-	d.Entry.OnSubmitted = func(s string) {
-		if d.OnSubmitted != nil {
-			d.OnSubmitted(d.readTime())
-		}
+	birth := NewDateEntry()
+	birth.MaxDate = time.Now() // no future birth dates
+	birth.Required = true
+	birthForm := widget.NewForm(widget.NewFormItem("Birth date", birth))
+	birthForm.OnSubmit = func() {
+		dialog.ShowInformation("Birth date", "Born on "+birth.GetTime().Format("Monday, January 2, 2006"), w)
 	}
-	d.Entry.OnChanged = func(s string) {
-		d.Validate()
 
-		tm := d.readTime()
-		valid := !tm.IsZero()
-
-		if valid != d.valid {
-			if d.OnChanged != nil {
-				d.OnChanged(tm)
-			}
-			d.valid = valid
-		}
+	appointment := NewDateTimeEntry()
+	appointment.MinDate = time.Now()
+	appointment.DateAllowed = func(tm time.Time) bool {
+		return tm.Weekday() != time.Saturday && tm.Weekday() != time.Sunday
 	}
-	return d
-}
 
-func (d *DateEntry) callOnChanged() {
-	d.Entry.OnChanged(d.Text)
-}
-
-// SetString will set date entry text.
-// Only dates in format 02/01/2006 are accepted. Any other caracter in s will be ignored.
-func (d *DateEntry) SetString(s string) {
-	d.Text = "__/__/____"
-	d.CursorColumn = 0
-	for _, r := range s {
-		d.TypedRune(r)
+	period := NewDateRangeEntry()
+	period.OnChanged = func(from, to time.Time) {
+		if !from.IsZero() && !to.IsZero() {
+			w.SetTitle(fmt.Sprintf("Date Entry - %d days", int(to.Sub(from).Hours()/24+0.5)+1))
+		}
 	}
-	d.valid = !d.readTime().IsZero()
-	d.Refresh()
-}
 
-// GetString returns the currently entered date in string format (02/01/2006).
-// If entered date is not valid, it will return empty string.
-func (d *DateEntry) GetString() string {
-	tm, err := time.ParseInLocation("02/01/2006", d.Text, time.Local)
-	if err != nil || tm.IsZero() {
-		return ""
+	clock := NewTimeEntry()
+	clock.OnSubmitted = func(tm time.Time) {
+		dialog.ShowInformation("Time Entry", "Entered time: "+tm.Format("3:04:05 PM"), w)
 	}
-	return d.Text
-}
+	clockFormat := widget.NewSelect([]string{LayoutTime24, LayoutTime24Seconds, LayoutTime12, LayoutTime12Seconds}, func(layout string) {
+		clock.SetLayout(layout)
+	})
+	clockFormat.SetSelected(LayoutTime24)
 
-// SetTime will set currently displayed date to tm.
-// If tm.IsZero(), it will set empty date (__/__/____).
-func (d *DateEntry) SetTime(tm time.Time) {
-	if tm.IsZero() {
-		d.Text = "__/__/____"
-		d.CursorColumn = 0
-	} else {
-		d.Text = tm.Format("02/01/2006")
-		d.CursorColumn = 10
+	datetime := NewDateTimeEntry()
+	datetime.OnSubmitted = func(tm time.Time) {
+		dialog.ShowInformation("Date Time Entry", "Entered date: "+tm.Format("Monday, January 2, 2006 at 15:04 MST"), w)
 	}
-	d.valid = !d.readTime().IsZero()
-	d.Refresh()
-}
-
-// GetTime wil return the currently entered date as time.Time.
-// If entered date is not valid, it will return a zero time object.
-func (d *DateEntry) GetTime() (tm time.Time) {
-	tm, _ = time.ParseInLocation("02/01/2006", d.Text, time.Local)
-	return
-}
+	utc := widget.NewCheck("UTC", datetime.SetUTC)
 
-func (d *DateEntry) MinSize() fyne.Size {
-	s := d.Entry.MinSize()
-	s.Width = fyne.MeasureText("00/00/0000", theme.TextSize(), d.TextStyle).Width + 2*theme.InnerPadding() + 2*theme.InputBorderSize()
-	return s
-}
+	w.SetContent(container.NewBorder(container.NewVBox(
+		widget.NewLabel("Date"), format, date,
+		widget.NewLabel("Time"), clockFormat, clock,
+		widget.NewLabel("Date and time"), container.NewBorder(nil, nil, nil, utc, datetime),
+		widget.NewLabel("Birth date (required, not in the future)"), birthForm,
+		widget.NewLabel("Appointment (from today, not on weekends)"), appointment,
+		widget.NewLabel("Period"), period,
+	), nil, nil, nil, layout.NewSpacer()))
 
-func (d *DateEntry) TypedRune(r rune) {
-	switch r {
-	case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-		switch d.CursorColumn {
-		// __/__/____ 0, 1, /2, 3, 4, /5, 6, 7, 8, 9 [, 10]
-		case 0, 1, 2, 3, 4, 5, 6, 7, 8, 9:
-			t := []rune(d.Text)
-			if d.CursorColumn == 2 || d.CursorColumn == 5 {
-				d.CursorColumn += 1
-			}
-			t[d.CursorColumn] = r
-			d.CursorColumn += 1
-			if d.CursorColumn == 2 || d.CursorColumn == 5 {
-				d.CursorColumn += 1
-			}
-			d.Text = string(t)
-			d.callOnChanged()
-			d.Refresh()
-		}
-	}
-}
-
-func (d *DateEntry) TypedKey(k *fyne.KeyEvent) {
-	switch k.Name {
-	case fyne.KeyRight:
-		d.CursorColumn += 1
-		if d.CursorColumn >= 10 {
-			d.CursorColumn = 10
-		}
-		if d.CursorColumn == 2 || d.CursorColumn == 5 {
-			d.CursorColumn += 1
-		}
-	case fyne.KeyLeft:
-		d.CursorColumn -= 1
-		if d.CursorColumn <= 0 {
-			d.CursorColumn = 0
-		}
-		if d.CursorColumn == 2 || d.CursorColumn == 5 {
-			d.CursorColumn -= 1
-		}
-	case fyne.KeyUp:
-		switch d.CursorColumn {
-		case 0, 1, 2:
-			d.setDay(d.getDay()+1, true)
-		case 3, 4, 5:
-			d.setMonth(d.getMonth()+1, true)
-		case 6, 7, 8, 9, 10:
-			d.setYear(d.getYear() + 1)
-		}
-		d.callOnChanged()
-	case fyne.KeyDown:
-		switch d.CursorColumn {
-		case 0, 1, 2:
-			d.setDay(d.getDay()-1, true)
-		case 3, 4, 5:
-			d.setMonth(d.getMonth()-1, true)
-		case 6, 7, 8, 9, 10:
-			d.setYear(d.getYear() - 1)
-		}
-		d.callOnChanged()
-	case fyne.KeyBackspace:
-		// __/__/____ 0, 1, /2, 3, 4, /5, 6, 7, 8, 9 [, 10]
-		t := []rune(d.Text)
-		switch d.CursorColumn {
-		case 10, 9, 8, 7, 5, 4, 2, 1:
-			t[d.CursorColumn-1] = '_'
-			d.CursorColumn -= 1
-			if d.CursorColumn == 3 || d.CursorColumn == 6 {
-				d.CursorColumn -= 1
-			}
-		case 3, 6:
-			d.CursorColumn -= 1
-		}
-		d.Text = string(t)
-		d.callOnChanged()
-	case fyne.KeyEnter, fyne.KeyReturn:
-		d.Entry.TypedKey(k)
-	case fyne.KeyDelete, fyne.KeyEscape:
-		d.Text = "__/__/____"
-		d.CursorColumn = 0
-		d.callOnChanged()
-	default:
-		return
-	}
-	d.Refresh()
+	w.ShowAndRun()
 }
 
-func (d *DateEntry) TypedShortcut(shortcut fyne.Shortcut) {
-	if s, ok := shortcut.(*fyne.ShortcutPaste); ok {
-		for _, r := range s.Clipboard.Content() {
-			d.TypedRune(r)
-		}
-	} else {
-		d.Entry.TypedShortcut(shortcut)
-	}
+// DateEntry is a widget.Entry that only accept a date input.
+// Date format is 02/01/2006 (dd/mm/yyyy) by default, see SetLayout.
+// The date can also be picked in a calendar (button on the right of the entry).
+//
+// OnSubmitted is also called when a date is picked in the calendar.
+type DateEntry struct {
+	maskEntry
 }
 
-// ------------------------------------------------------------------------------------------------
-
-func (d *DateEntry) readTime() time.Time {
-	tm, _ := time.ParseInLocation("02/01/2006", d.Text, time.Local)
-	return tm
+// NewDateEntry creates a new DateEntry.
+func NewDateEntry() *DateEntry {
+	d := &DateEntry{}
+	d.ExtendBaseWidget(d)
+	m, _ := newDateMask(LayoutDMY)
+	d.init(d, m)
+	d.ActionItem = widget.NewButtonWithIcon("", calendarIcon, d.ShowCalendar)
+	return d
 }
 
-func (d *DateEntry) setDay(day int, loop bool) {
-	maxDay := 30
-	switch d.getMonth() {
-	case 4, 6, 9, 11:
-		maxDay = 30
-	case 1, 3, 5, 7, 8, 10, 12:
-		maxDay = 31
-	case 2:
-		year := d.getYear()
-		if year == 0 {
-			maxDay = 28
-		} else {
-			if year%400 == 0 || (year%4 == 0 && year%100 != 0) {
-				maxDay = 29
-			} else {
-				maxDay = 28
-			}
-		}
-	}
-	if day > maxDay {
-		if loop {
-			day = 1
-		} else {
-			day = maxDay
-		}
-	}
-	if day < 1 {
-		if loop {
-			day = maxDay
-		} else {
-			day = 1
-		}
-	}
-	d.Text = fmt.Sprintf("%02d", day) + d.Text[2:]
-}
-func (d *DateEntry) getDay() int {
-	ret, err := strconv.Atoi(d.Text[:2]) // __/__/____
+// SetLayout changes the date format, keeping the entered date.
+// layout is a time layout (see LayoutDMY, LayoutMDY, LayoutISO) made of 02 (day), 01 (month)
+// and 2006 (year) in any order, separated by any other characters.
+func (d *DateEntry) SetLayout(layout string) error {
+	m, err := newDateMask(layout)
 	if err != nil {
-		return 0
+		return err
 	}
-	return ret
+	d.setMask(m)
+	return nil
 }
 
-func (d *DateEntry) setMonth(month int, loop bool) {
-	if month > 12 {
-		if loop {
-			month = 1
-		} else {
-			month = 12
-		}
-	}
-	if month < 1 {
-		if loop {
-			month = 12
-		} else {
-			month = 1
-		}
-	}
-	sMonth := fmt.Sprintf("%02d", month)
-	d.Text = d.Text[:3] + sMonth + d.Text[5:]
-}
-func (d *DateEntry) getMonth() int {
-	ret, err := strconv.Atoi(d.Text[3:5]) // __/__/____
-	if err != nil {
-		return 0
-	}
-	return ret
+// ShowCalendar displays a calendar under the entry, to pick a date.
+// The picked date is written like a typed one: OnChanged and OnSubmitted are called.
+func (d *DateEntry) ShowCalendar() {
+	d.showCalendar()
 }
 
-func (d *DateEntry) setYear(year int) {
-	if year > 9999 {
-		year = 9999
-	}
-	if year < 1 {
-		year = 1
-	}
-	sYear := fmt.Sprintf("%04d", year)
-	d.Text = d.Text[:6] + sYear
-}
-func (d *DateEntry) getYear() int {
-	ret, err := strconv.Atoi(d.Text[6:]) // __/__/____
-	if err != nil {
-		return 0
-	}
-	return ret
+// HideCalendar hides the calendar, if displayed.
+func (d *DateEntry) HideCalendar() {
+	d.hideCalendar()
 }
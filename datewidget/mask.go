@@ -0,0 +1,247 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Date layouts accepted by DateEntry.SetLayout (see the time package for their syntax).
+const (
+	LayoutDMY = "02/01/2006" // dd/mm/yyyy
+	LayoutMDY = "01/02/2006" // mm/dd/yyyy
+	LayoutISO = "2006-01-02" // yyyy-mm-dd
+)
+
+// Time layouts accepted by TimeEntry.SetLayout.
+const (
+	LayoutTime24        = "15:04"       // HH:MM
+	LayoutTime24Seconds = "15:04:05"    // HH:MM:SS
+	LayoutTime12        = "03:04 PM"    // HH:MM AM
+	LayoutTime12Seconds = "03:04:05 PM" // HH:MM:SS AM
+)
+
+type fieldKind int
+
+const (
+	fieldDay fieldKind = iota
+	fieldMonth
+	fieldYear
+	fieldHour   // 00-23
+	fieldHour12 // 01-12
+	fieldMinute
+	fieldSecond
+	fieldAMPM // AM or PM, not a number
+)
+
+// maskTokens are the elements of a time layout that can be used in a mask, longest first.
+var maskTokens = []struct {
+	token string
+	kind  fieldKind
+}{
+	{"2006", fieldYear},
+	{"01", fieldMonth},
+	{"02", fieldDay},
+	{"15", fieldHour},
+	{"03", fieldHour12},
+	{"04", fieldMinute},
+	{"05", fieldSecond},
+	{"PM", fieldAMPM},
+}
+
+// maskField is an editable field of a mask: runes [start, start+width) of the text.
+type maskField struct {
+	kind         fieldKind
+	start, width int
+}
+
+func (f maskField) end() int { return f.start + f.width }
+
+// mask is the editing template of a date or time entry, built from a time layout:
+// the fields, in display order, and the literal separators between them.
+type mask struct {
+	layout string
+	fields []maskField
+	empty  []rune // text with empty fields (__/__/____)
+}
+
+// newMask parses layout. Each field must appear once, separated by any other characters.
+func newMask(layout string) (*mask, error) {
+	m := &mask{layout: layout}
+	for rest := layout; rest != ""; {
+		found := false
+		for _, tok := range maskTokens {
+			if strings.HasPrefix(rest, tok.token) {
+				if m.has(tok.kind) {
+					return nil, fmt.Errorf("layout %q: %s appears twice", layout, tok.token)
+				}
+				width := len(tok.token)
+				m.fields = append(m.fields, maskField{kind: tok.kind, start: len(m.empty), width: width})
+				m.empty = append(m.empty, []rune(strings.Repeat("_", width))...)
+				rest = rest[len(tok.token):]
+				found = true
+				break
+			}
+		}
+		if !found {
+			r := []rune(rest)[0]
+			if r >= '0' && r <= '9' || r == '_' {
+				return nil, fmt.Errorf("layout %q: unexpected %q", layout, r)
+			}
+			m.empty = append(m.empty, r)
+			rest = rest[len(string(r)):]
+		}
+	}
+	if m.has(fieldHour12) != m.has(fieldAMPM) {
+		return nil, fmt.Errorf("layout %q: 03 (12h hour) and PM go together", layout)
+	}
+	return m, nil
+}
+
+// newDateMask parses a layout with a day (02), a month (01) and a year (2006).
+func newDateMask(layout string) (*mask, error) {
+	m, err := newMask(layout)
+	if err != nil {
+		return nil, err
+	}
+	if !m.has(fieldDay) || !m.has(fieldMonth) || !m.has(fieldYear) {
+		return nil, fmt.Errorf("layout %q: day (02), month (01) and year (2006) are required", layout)
+	}
+	return m, nil
+}
+
+// newTimeMask parses a layout with an hour (15, or 03 and PM) and minutes (04).
+func newTimeMask(layout string) (*mask, error) {
+	m, err := newMask(layout)
+	if err != nil {
+		return nil, err
+	}
+	if !m.has(fieldHour) && !m.has(fieldHour12) || !m.has(fieldMinute) {
+		return nil, fmt.Errorf("layout %q: hour (15 or 03) and minutes (04) are required", layout)
+	}
+	return m, nil
+}
+
+// newDateTimeMask parses a layout with both date and time fields.
+func newDateTimeMask(layout string) (*mask, error) {
+	if _, err := newDateMask(layout); err != nil {
+		return nil, err
+	}
+	return newTimeMask(layout)
+}
+
+// has reports whether the mask has a field of kind.
+func (m *mask) has(kind fieldKind) bool {
+	for _, f := range m.fields {
+		if f.kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDate reports whether the mask has date fields.
+func (m *mask) hasDate() bool {
+	return m.has(fieldDay)
+}
+
+// hasTime reports whether the mask has time fields.
+func (m *mask) hasTime() bool {
+	return m.has(fieldHour) || m.has(fieldHour12)
+}
+
+// isSeparator reports whether the rune at col is not part of a field.
+func (m *mask) isSeparator(col int) bool {
+	if col < 0 || col >= len(m.empty) {
+		return false
+	}
+	for _, f := range m.fields {
+		if col >= f.start && col < f.end() {
+			return false
+		}
+	}
+	return true
+}
+
+// isSeparatorRune reports whether r is one of the separators of the mask.
+func (m *mask) isSeparatorRune(r rune) bool {
+	for col, c := range m.empty {
+		if c == r && m.isSeparator(col) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldAt returns the field the cursor at col is in (or right after), false if there is none.
+func (m *mask) fieldAt(col int) (maskField, bool) {
+	for _, f := range m.fields {
+		if col >= f.start && col <= f.end() {
+			return f, true
+		}
+	}
+	return maskField{}, false
+}
+
+// field returns the field of kind.
+func (m *mask) field(kind fieldKind) maskField {
+	for _, f := range m.fields {
+		if f.kind == kind {
+			return f
+		}
+	}
+	return maskField{}
+}
+
+// skipForward returns col, moved forward past the separators.
+func (m *mask) skipForward(col int) int {
+	for m.isSeparator(col) {
+		col++
+	}
+	return col
+}
+
+// skipBackward returns col, moved backward before the separators.
+func (m *mask) skipBackward(col int) int {
+	for col > 0 && m.isSeparator(col) {
+		col--
+	}
+	return col
+}
+
+// skipBackwardAfter returns col, moved backward before the separators preceding it.
+func (m *mask) skipBackwardAfter(col int) int {
+	for col > 0 && m.isSeparator(col-1) {
+		col--
+	}
+	return col
+}
+
+// hint is the format of the mask, for the user (dd/mm/yyyy).
+func (m *mask) hint() string {
+	t := append([]rune(nil), m.empty...)
+	for _, f := range m.fields {
+		copy(t[f.start:f.end()], []rune(fieldHints[f.kind]))
+	}
+	return string(t)
+}
+
+var fieldHints = map[fieldKind]string{
+	fieldDay:    "dd",
+	fieldMonth:  "mm",
+	fieldYear:   "yyyy",
+	fieldHour:   "hh",
+	fieldHour12: "hh",
+	fieldMinute: "mm",
+	fieldSecond: "ss",
+	fieldAMPM:   "AM",
+}
+
+// placeholder is the text measured for the width of the entry (00/00/0000).
+func (m *mask) placeholder() string {
+	t := []rune(strings.ReplaceAll(string(m.empty), "_", "0"))
+	if m.has(fieldAMPM) {
+		f := m.field(fieldAMPM)
+		copy(t[f.start:f.end()], []rune("PM"))
+	}
+	return string(t)
+}
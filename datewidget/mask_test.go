@@ -0,0 +1,89 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestNewMask(t *testing.T) {
+	m, err := newMask(LayoutISO)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(m.empty) != "____-__-__" {
+		t.Errorf("unexpected empty text %q", string(m.empty))
+	}
+	want := []maskField{{fieldYear, 0, 4}, {fieldMonth, 5, 2}, {fieldDay, 8, 2}}
+	for i, f := range m.fields {
+		if f != want[i] {
+			t.Errorf("field %d: expected %v, got %v", i, want[i], f)
+		}
+	}
+
+	for _, layout := range []string{"02/01", "02/01/2006/02", "02/01/2006 3"} {
+		if _, err := newDateMask(layout); err == nil {
+			t.Errorf("%q: expected an error", layout)
+		}
+	}
+}
+
+func TestDateEntry_Layouts(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+
+	want := time.Date(2024, time.February, 29, 0, 0, 0, 0, time.Local)
+	for layout, typed := range map[string]string{
+		LayoutDMY: "29022024",
+		LayoutMDY: "02292024",
+		LayoutISO: "20240229",
+	} {
+		d := NewDateEntry()
+		if err := d.SetLayout(layout); err != nil {
+			t.Fatal(err)
+		}
+		d.SetString(typed)
+		if !d.GetTime().Equal(want) {
+			t.Errorf("%s: expected %v, got %v (%q)", layout, want, d.GetTime(), d.Text)
+		}
+		if d.GetString() != want.Format(layout) {
+			t.Errorf("%s: unexpected string %q", layout, d.GetString())
+		}
+	}
+}
+
+func TestDateEntry_TypedKey(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+
+	d := NewDateEntry()
+	d.SetLayout(LayoutISO)
+	d.SetString("20240131")
+
+	// up on the month: the day is kept
+	d.CursorColumn = 6
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyUp})
+	if d.Text != "2024-02-31" {
+		t.Errorf("expected the month to be incremented, got %q", d.Text)
+	}
+
+	// left skips the separators
+	d.CursorColumn = 5
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyLeft})
+	if d.CursorColumn != 3 {
+		t.Errorf("expected the cursor before the separator to be skipped, got %d", d.CursorColumn)
+	}
+
+	// backspace clears the previous digit and skips the separator
+	d.CursorColumn = 6
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+	if d.Text != "2024-_2-31" || d.CursorColumn != 4 {
+		t.Errorf("unexpected backspace result %q, cursor %d", d.Text, d.CursorColumn)
+	}
+	d.TypedRune('0')
+	if d.Text != "2024-02-31" || d.CursorColumn != 6 {
+		t.Errorf("unexpected typing result %q, cursor %d", d.Text, d.CursorColumn)
+	}
+}
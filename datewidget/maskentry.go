@@ -0,0 +1,790 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Validation errors of the entries, the returned errors wrap them with details
+// (errors.Is(err, ErrIncomplete)...).
+var (
+	ErrRequired       = errors.New("value required")
+	ErrIncomplete     = errors.New("incomplete value")
+	ErrImpossibleDate = errors.New("impossible date")
+	ErrImpossibleTime = errors.New("impossible time")
+	ErrOutOfRange     = errors.New("date out of range")
+	ErrDateNotAllowed = errors.New("date not allowed")
+)
+
+// maskEntry is the editing engine of DateEntry, TimeEntry and DateTimeEntry: a widget.Entry
+// whose text is a mask made of fields separated by literal characters (__/__/____).
+//
+// Digits are written in the fields, the cursor skipping the separators, Up and Down
+// increment the field under the cursor, Backspace clears the previous digit (_).
+//
+// The entry is a fyne.Validatable: Validate returns why the text is not a valid value, and the
+// error is displayed like the one of a widget.Entry Validator. In a widget.Form, an invalid
+// entry disables the submit button.
+type maskEntry struct {
+	widget.Entry
+
+	OnChanged   func(time.Time) // Called when the data changes
+	OnSubmitted func(time.Time) // Called when Enter is pressed in the input
+
+	// MinDate and MaxDate, if not zero, bound the dates that can be entered (compared by day).
+	MinDate, MaxDate time.Time
+	// DateAllowed, if not nil, tells if a date (at midnight) can be entered.
+	DateAllowed func(time.Time) bool
+	// Required makes an empty entry not valid.
+	Required bool
+	// CenturyPivot expands the two-digit years: below it they are in the 2000s, the others in
+	// the 1900s. DefaultCenturyPivot is used if it is 0.
+	CenturyPivot int
+	// Location is the time zone of the entered value, time.Local if nil (see also SetUTC).
+	Location *time.Location
+
+	self     fyne.Widget // the extending widget
+	mask     *mask
+	date     time.Time // last date given to OnChanged, zero if not valid
+	set      time.Time // last value given to SetTime
+	utc      bool
+	calendar *widget.PopUp
+}
+
+// init must be called by the constructor of the extending widget, after ExtendBaseWidget.
+func (e *maskEntry) init(self fyne.Widget, m *mask) {
+	e.self = self
+	e.mask = m
+	e.Text = string(e.mask.empty)
+	e.Validator = e.validate
+	e.Entry.OnSubmitted = func(s string) {
+		if e.OnSubmitted != nil {
+			e.OnSubmitted(e.readTime())
+		}
+	}
+	e.Entry.OnChanged = func(s string) {
+		e.Validate()
+
+		tm := e.readTime()
+		if !tm.Equal(e.date) {
+			e.date = tm
+			if e.OnChanged != nil {
+				e.OnChanged(tm)
+			}
+		}
+	}
+}
+
+func (e *maskEntry) callOnChanged() {
+	e.Entry.OnChanged(e.Text)
+}
+
+// setMask changes the layout, keeping the entered value.
+func (e *maskEntry) setMask(m *mask) {
+	tm := e.GetTime()
+	e.mask = m
+	e.SetTime(tm)
+}
+
+// SetString will set the entry text.
+// s can be written in the layout format, in a common format (2024-03-15, 15 March 2024, RFC3339...)
+// or be a relative expression (today, +3d, next monday). Else, the digits of s are typed in the fields.
+// In both cases, OnChanged is called if the value changes.
+func (e *maskEntry) SetString(s string) {
+	if tm, ok := parseValue(s, e.mask, time.Now().In(e.location())); ok {
+		e.Text = e.format(tm)
+		e.CursorColumn = len(e.mask.empty)
+		e.set = tm
+		e.callOnChanged()
+		e.Refresh()
+		return
+	}
+	// OnChanged is called once, with the final value
+	date, onChanged := e.date, e.OnChanged
+	e.OnChanged = nil
+	e.Text = string(e.mask.empty)
+	e.CursorColumn = 0
+	for _, r := range s {
+		e.TypedRune(r)
+	}
+	e.completeYear()
+	e.date, e.OnChanged = date, onChanged
+	e.callOnChanged()
+	e.Refresh()
+}
+
+// GetString returns the currently entered value in string format (the layout).
+// If entered value is not valid, it will return empty string.
+func (e *maskEntry) GetString() string {
+	if e.readTime().IsZero() {
+		return ""
+	}
+	return e.Text
+}
+
+// SetTime will set currently displayed value to tm.
+// If tm.IsZero(), it will set an empty value (__/__/____).
+//
+// With time fields, tm is displayed in the location of the entry (the same instant, see Location).
+// A date entry displays the day of tm, in its own location.
+func (e *maskEntry) SetTime(tm time.Time) {
+	if tm.IsZero() {
+		e.Text = string(e.mask.empty)
+		e.CursorColumn = 0
+	} else {
+		e.Text = e.format(tm)
+		e.CursorColumn = len(e.mask.empty)
+	}
+	e.set = tm
+	e.date = e.readTime()
+	e.Validate()
+	e.Refresh()
+}
+
+// SetUTC displays (and parses) the value in UTC instead of Location, keeping the entered instant.
+func (e *maskEntry) SetUTC(utc bool) {
+	tm := e.GetTime()
+	e.utc = utc
+	if e.mask.hasTime() && !tm.IsZero() {
+		e.SetTime(tm)
+	}
+	e.callOnChanged()
+}
+
+// IsUTC reports whether the value is displayed in UTC, see SetUTC.
+func (e *maskEntry) IsUTC() bool {
+	return e.utc
+}
+
+// GetTime wil return the currently entered value as time.Time.
+// If entered value is not valid, or not allowed (see MinDate, MaxDate and DateAllowed), it will return a zero time object.
+func (e *maskEntry) GetTime() time.Time {
+	return e.readTime()
+}
+
+func (e *maskEntry) MinSize() fyne.Size {
+	s := e.Entry.MinSize()
+	s.Width = fyne.MeasureText(e.mask.placeholder(), theme.TextSize(), e.TextStyle).Width + 2*theme.InnerPadding() + 2*theme.InputBorderSize()
+	if e.ActionItem != nil {
+		s.Width += theme.IconInlineSize() + theme.LineSpacing()
+	}
+	if e.Validator != nil {
+		s.Width += theme.IconInlineSize() + theme.LineSpacing()
+	}
+	return s
+}
+
+func (e *maskEntry) TypedRune(r rune) {
+	col := e.mask.skipForward(e.CursorColumn)
+	f, ok := e.mask.fieldAt(col)
+	if !ok || col >= len(e.mask.empty) {
+		return
+	}
+	t := []rune(e.Text)
+	switch {
+	case r >= '0' && r <= '9' && f.kind != fieldAMPM:
+		// the cursor skips the separators: __/__/____ 0, 1, /2, 3, 4, /5, 6, 7, 8, 9 [, 10]
+		if !e.typeDigit(t, f, col, int(r-'0')) {
+			return
+		}
+	case e.mask.isSeparatorRune(r):
+		// 1/ is 01/, the cursor jumps to the next field
+		if !e.padField(f, col) {
+			return
+		}
+		e.callOnChanged()
+		e.Refresh()
+		return
+	case (r == 'a' || r == 'A' || r == 'p' || r == 'P') && f.kind == fieldAMPM:
+		ampm := "AM"
+		if r == 'p' || r == 'P' {
+			ampm = "PM"
+		}
+		copy(t[f.start:f.end()], []rune(ampm))
+		e.CursorColumn = e.mask.skipForward(f.end())
+	default:
+		return
+	}
+	e.Text = string(t)
+	e.callOnChanged()
+	e.Refresh()
+}
+
+// FocusLost expands a two-digit year.
+func (e *maskEntry) FocusLost() {
+	e.completeYear()
+	e.Entry.FocusLost()
+}
+
+func (e *maskEntry) TypedKey(k *fyne.KeyEvent) {
+	switch k.Name {
+	case fyne.KeyRight:
+		e.CursorColumn += 1
+		if e.CursorColumn >= len(e.mask.empty) {
+			e.CursorColumn = len(e.mask.empty)
+		}
+		e.CursorColumn = e.mask.skipForward(e.CursorColumn)
+	case fyne.KeyLeft:
+		e.CursorColumn -= 1
+		if e.CursorColumn <= 0 {
+			e.CursorColumn = 0
+		}
+		e.CursorColumn = e.mask.skipBackward(e.CursorColumn)
+	case fyne.KeyUp:
+		if f, ok := e.mask.fieldAt(e.CursorColumn); ok {
+			e.increment(f.kind, 1)
+		}
+		e.callOnChanged()
+	case fyne.KeyDown:
+		if f, ok := e.mask.fieldAt(e.CursorColumn); ok {
+			e.increment(f.kind, -1)
+		}
+		e.callOnChanged()
+	case fyne.KeyBackspace:
+		t := []rune(e.Text)
+		if f, ok := e.mask.fieldAt(e.CursorColumn - 1); ok && f.kind == fieldAMPM {
+			copy(t[f.start:f.end()], e.mask.empty[f.start:f.end()])
+			e.CursorColumn = f.start
+		} else if e.CursorColumn > 0 && !e.mask.isSeparator(e.CursorColumn-1) {
+			t[e.CursorColumn-1] = '_'
+			e.CursorColumn -= 1
+		}
+		e.CursorColumn = e.mask.skipBackwardAfter(e.CursorColumn)
+		e.Text = string(t)
+		e.callOnChanged()
+	case fyne.KeyEnter, fyne.KeyReturn:
+		e.completeYear()
+		e.Entry.TypedKey(k)
+	case fyne.KeyDelete, fyne.KeyEscape:
+		e.Text = string(e.mask.empty)
+		e.CursorColumn = 0
+		e.callOnChanged()
+	default:
+		return
+	}
+	e.Refresh()
+}
+
+func (e *maskEntry) TypedShortcut(shortcut fyne.Shortcut) {
+	if s, ok := shortcut.(*fyne.ShortcutPaste); ok {
+		// a value that can be parsed replaces the text, else its caracters are typed
+		if tm, ok := parseValue(s.Clipboard.Content(), e.mask, time.Now().In(e.location())); ok {
+			e.Text = e.format(tm)
+			e.CursorColumn = len(e.mask.empty)
+			e.callOnChanged()
+			e.Refresh()
+			return
+		}
+		for _, r := range s.Clipboard.Content() {
+			e.TypedRune(r)
+		}
+	} else {
+		e.Entry.TypedShortcut(shortcut)
+	}
+}
+
+// ------------------------------------------------------------------------------------------------
+
+// DefaultCenturyPivot is the default CenturyPivot, the one of time.Parse (69 is 1969, 68 is 2068).
+const DefaultCenturyPivot = 69
+
+// typeDigit writes digit at col, in the field f of t, and moves the cursor.
+// In a two-digit field, a first digit that can't start a valid value is padded (4 is 04 in the
+// day field) and the cursor jumps to the next field, as is a first digit typed over a second digit
+// it can't go with (3 over 19), unless it can't be padded (0 over 10): the second digit is then
+// cleared. A second digit that makes an impossible value is refused.
+func (e *maskEntry) typeDigit(t []rune, f maskField, col int, digit int) bool {
+	min, max := e.fieldRange(f.kind)
+	if f.width == 2 && max > 0 {
+		switch col - f.start {
+		case 0:
+			pad := digit*10 > max
+			if second := t[col+1]; !pad && second >= '0' && second <= '9' {
+				// typed over a complete field: the second digit must still make a valid value
+				if v := digit*10 + int(second-'0'); v < min || v > max {
+					if digit < min {
+						t[col], t[col+1] = '0'+rune(digit), e.mask.empty[col+1]
+						e.CursorColumn = col + 1
+						return true
+					}
+					pad = true
+				}
+			}
+			if pad {
+				t[col], t[col+1] = '0', '0'+rune(digit)
+				e.CursorColumn = e.mask.skipForward(f.end())
+				return true
+			}
+		case 1:
+			if first := t[col-1]; first >= '0' && first <= '9' {
+				if v := int(first-'0')*10 + digit; v < min || v > max {
+					return false
+				}
+			}
+		}
+	}
+	t[col] = '0' + rune(digit)
+	e.CursorColumn = e.mask.skipForward(col + 1)
+	return true
+}
+
+// padField completes the field f when only its first digits, before col, are typed: they are
+// right-aligned (1 is 01) or, in the year field, two digits are expanded with CenturyPivot.
+// The cursor jumps to the next field.
+func (e *maskEntry) padField(f maskField, col int) bool {
+	t := []rune(e.Text)
+	if f.kind == fieldAMPM || col <= f.start || col >= f.end() || strings.Trim(string(t[col:f.end()]), "_") != "" {
+		return false
+	}
+	typed := string(t[f.start:col])
+	v, err := strconv.Atoi(typed)
+	if err != nil {
+		return false
+	}
+	if f.kind == fieldYear && len(typed) == 2 {
+		pivot := e.CenturyPivot
+		if pivot == 0 {
+			pivot = DefaultCenturyPivot
+		}
+		if v < pivot {
+			v += 2000
+		} else {
+			v += 1900
+		}
+	}
+	if min, max := e.fieldRange(f.kind); max > 0 && (v < min || v > max) {
+		return false
+	}
+	e.setField(f.kind, v)
+	e.CursorColumn = e.mask.skipForward(f.end())
+	return true
+}
+
+// completeYear expands a two-digit year, when the entry is left or submitted.
+func (e *maskEntry) completeYear() {
+	if !e.mask.has(fieldYear) {
+		return
+	}
+	f := e.mask.field(fieldYear)
+	cursor := e.CursorColumn
+	if e.padField(f, f.start+2) {
+		e.CursorColumn = cursor
+		e.callOnChanged()
+		e.Refresh()
+	}
+}
+
+// fieldRange returns the possible values of a field of kind, max is 0 for the year (not checked).
+// The days are the ones of the entered month, February having 29 days if the year is not complete.
+func (e *maskEntry) fieldRange(kind fieldKind) (min, max int) {
+	switch kind {
+	case fieldDay:
+		month, year := e.getMonth(), e.getYear()
+		if month < 1 || month > 12 {
+			return 1, 31
+		}
+		if year == 0 {
+			year = 2000 // a leap year
+		}
+		return 1, time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.Local).Day()
+	case fieldMonth:
+		return 1, 12
+	case fieldHour:
+		return 0, 23
+	case fieldHour12:
+		return 1, 12
+	case fieldMinute, fieldSecond:
+		return 0, 59
+	}
+	return 0, 0
+}
+
+// showCalendar displays a calendar under the entry, to pick a date.
+// The picked date is written like a typed one: OnChanged and OnSubmitted are called.
+func (e *maskEntry) showCalendar() {
+	cnv := fyne.CurrentApp().Driver().CanvasForObject(e.self)
+	if cnv == nil {
+		return
+	}
+	cal := newCalendar(e.fieldsDate(), e.dateAllowed)
+	cal.OnPicked = e.pick
+	cal.OnCancel = e.hideCalendar
+	e.hideCalendar()
+	e.calendar = widget.NewPopUp(cal, cnv)
+	e.calendar.ShowAtPosition(fyne.CurrentApp().Driver().AbsolutePositionForObject(e.self).Add(fyne.NewPos(0, e.Size().Height)))
+	cnv.Focus(cal)
+}
+
+func (e *maskEntry) hideCalendar() {
+	if e.calendar != nil {
+		e.calendar.Hide()
+		e.calendar = nil
+	}
+}
+
+// pick writes the date fields, the time fields are kept (set to midnight if they are not complete).
+func (e *maskEntry) pick(tm time.Time) {
+	e.hideCalendar()
+	e.setDate(tm)
+	for _, f := range e.mask.fields {
+		if !strings.ContainsRune(string([]rune(e.Text)[f.start:f.end()]), '_') {
+			continue
+		}
+		switch f.kind {
+		case fieldHour, fieldMinute, fieldSecond:
+			e.setField(f.kind, 0)
+		case fieldHour12:
+			e.setField(f.kind, 12)
+		case fieldAMPM:
+			t := []rune(e.Text)
+			copy(t[f.start:f.end()], []rune("AM"))
+			e.Text = string(t)
+		}
+	}
+	e.CursorColumn = len(e.mask.empty)
+	e.callOnChanged()
+	e.Refresh()
+	if cnv := fyne.CurrentApp().Driver().CanvasForObject(e.self); cnv != nil {
+		cnv.Focus(e.self.(fyne.Focusable))
+	}
+	e.Entry.OnSubmitted(e.Text)
+}
+
+// setDate writes the date fields.
+func (e *maskEntry) setDate(tm time.Time) {
+	e.setField(fieldDay, tm.Day())
+	e.setField(fieldMonth, int(tm.Month()))
+	e.setField(fieldYear, tm.Year())
+}
+
+// fieldsDate returns the date entered in the date fields, zero if they are not valid.
+func (e *maskEntry) fieldsDate() time.Time {
+	y, m, d := e.getYear(), e.getMonth(), e.getDay()
+	tm := time.Date(y, time.Month(m), d, 0, 0, 0, 0, time.Local)
+	if y == 0 || tm.Day() != d || tm.Month() != time.Month(m) {
+		return time.Time{}
+	}
+	return tm
+}
+
+// ------------------------------------------------------------------------------------------------
+
+// location returns the time zone of the value: UTC, Location or time.Local.
+func (e *maskEntry) location() *time.Location {
+	if e.utc {
+		return time.UTC
+	}
+	if e.Location != nil {
+		return e.Location
+	}
+	return time.Local
+}
+
+// format returns the text of tm: its instant in the location of the entry, or its day for a date entry.
+func (e *maskEntry) format(tm time.Time) string {
+	if e.mask.hasTime() {
+		tm = tm.In(e.location())
+	}
+	return tm.Format(e.mask.layout)
+}
+
+// readTime parses the text, a valid date that is not allowed is returned as zero.
+func (e *maskEntry) readTime() time.Time {
+	tm, err := e.parse(e.Text)
+	if err != nil || e.mask.hasDate() && !e.dateAllowed(tm) {
+		return time.Time{}
+	}
+	return e.keepInstant(tm)
+}
+
+// parse parses the complete text s in the location of the entry. A time skipped when the clocks
+// go forward (02:30 when they go from 02:00 to 03:00) is an error, not the time an hour later.
+func (e *maskEntry) parse(s string) (time.Time, error) {
+	tm, err := time.ParseInLocation(e.mask.layout, s, e.location())
+	if err == nil && tm.Format(e.mask.layout) != s {
+		return time.Time{}, fmt.Errorf("%w: %s is skipped in %s", ErrImpossibleTime, s, tm.Location())
+	}
+	return tm, err
+}
+
+// keepInstant returns the instant given to SetTime, instead of tm, when they are displayed the same:
+// when the clocks go back, an hour is repeated and ParseInLocation returns its first occurrence.
+func (e *maskEntry) keepInstant(tm time.Time) time.Time {
+	if e.set.IsZero() || !e.mask.hasDate() || !e.mask.hasTime() {
+		return tm
+	}
+	set := e.set.In(e.location())
+	if set.Format(e.mask.layout) != e.Text {
+		return tm
+	}
+	_, offset := tm.Zone()
+	_, setOffset := set.Zone()
+	if offset == setOffset {
+		return tm
+	}
+	// tm with the offset of set, truncated like the text
+	return tm.Add(time.Duration(offset-setOffset) * time.Second).In(e.location())
+}
+
+// validate is the Validator of the entry.
+func (e *maskEntry) validate(s string) error {
+	if s == string(e.mask.empty) {
+		if e.Required {
+			return ErrRequired
+		}
+		return nil
+	}
+	if strings.ContainsRune(s, '_') {
+		return fmt.Errorf("%w, expected %s", ErrIncomplete, e.mask.hint())
+	}
+	tm, err := e.parse(s)
+	if errors.Is(err, ErrImpossibleTime) {
+		return err
+	} else if err != nil {
+		return e.impossible(s, err)
+	}
+	if !e.mask.hasDate() {
+		return nil
+	}
+	day := dateOf(tm)
+	if !e.MinDate.IsZero() && day.Before(dateOf(e.MinDate)) {
+		return fmt.Errorf("%w: before %s", ErrOutOfRange, e.MinDate.Format("January 2, 2006"))
+	}
+	if !e.MaxDate.IsZero() && day.After(dateOf(e.MaxDate)) {
+		return fmt.Errorf("%w: after %s", ErrOutOfRange, e.MaxDate.Format("January 2, 2006"))
+	}
+	if e.DateAllowed != nil && !e.DateAllowed(day) {
+		return fmt.Errorf("%w: %s", ErrDateNotAllowed, day.Format("Monday, January 2, 2006"))
+	}
+	return nil
+}
+
+// impossible explains why the complete text s could not be parsed.
+func (e *maskEntry) impossible(s string, err error) error {
+	value := func(kind fieldKind) int {
+		f := e.mask.field(kind)
+		v, _ := strconv.Atoi(string([]rune(s)[f.start:f.end()]))
+		return v
+	}
+	for _, f := range e.mask.fields {
+		v := value(f.kind)
+		switch f.kind {
+		case fieldMonth:
+			if v < 1 || v > 12 {
+				return fmt.Errorf("%w: no month %d", ErrImpossibleDate, v)
+			}
+		case fieldDay:
+			if v < 1 {
+				return fmt.Errorf("%w: no day %d", ErrImpossibleDate, v)
+			}
+		case fieldHour:
+			if v > 23 {
+				return fmt.Errorf("%w: no hour %d", ErrImpossibleTime, v)
+			}
+		case fieldHour12:
+			if v < 1 || v > 12 {
+				return fmt.Errorf("%w: no hour %d in 12-hour format", ErrImpossibleTime, v)
+			}
+		case fieldMinute, fieldSecond:
+			if v > 59 {
+				return fmt.Errorf("%w: %d is more than 59", ErrImpossibleTime, v)
+			}
+		}
+	}
+	if e.mask.hasDate() {
+		month := time.Date(value(fieldYear), time.Month(value(fieldMonth)), 1, 0, 0, 0, 0, time.Local)
+		if days := month.AddDate(0, 1, -1).Day(); value(fieldDay) > days {
+			return fmt.Errorf("%w: %s has %d days", ErrImpossibleDate, month.Format("January 2006"), days)
+		}
+	}
+	return err
+}
+
+// dateAllowed checks the day of tm against MinDate, MaxDate and DateAllowed.
+func (e *maskEntry) dateAllowed(tm time.Time) bool {
+	day := dateOf(tm)
+	if !e.MinDate.IsZero() && day.Before(dateOf(e.MinDate)) {
+		return false
+	}
+	if !e.MaxDate.IsZero() && day.After(dateOf(e.MaxDate)) {
+		return false
+	}
+	return e.DateAllowed == nil || e.DateAllowed(day)
+}
+
+// dateOf returns the day of tm, at midnight.
+func dateOf(tm time.Time) time.Time {
+	y, m, d := tm.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
+
+// increment adds step to the field of kind, looping (days, months, time fields) or clamping (years).
+// When the date fields are complete, the date is clamped in [MinDate, MaxDate], and the disallowed
+// dates are skipped. If no date is allowed in the direction of step, the text is not changed.
+func (e *maskEntry) increment(kind fieldKind, step int) {
+	if kind != fieldDay && kind != fieldMonth && kind != fieldYear {
+		e.incrementField(kind, step)
+		return
+	}
+	text := e.Text
+	for i := 0; i < maxIncrements; i++ {
+		e.incrementField(kind, step)
+		tm := e.fieldsDate()
+		if tm.IsZero() {
+			return
+		}
+		if !e.MinDate.IsZero() && tm.Before(dateOf(e.MinDate)) {
+			e.setDate(e.MinDate)
+		} else if !e.MaxDate.IsZero() && tm.After(dateOf(e.MaxDate)) {
+			e.setDate(e.MaxDate)
+		}
+		if e.dateAllowed(e.fieldsDate()) {
+			return
+		}
+	}
+	e.Text = text
+}
+
+// maxIncrements bounds the search of an allowed date by increment.
+const maxIncrements = 1000
+
+func (e *maskEntry) incrementField(kind fieldKind, step int) {
+	switch kind {
+	case fieldDay:
+		e.setDay(e.getDay()+step, true)
+	case fieldMonth:
+		e.setMonth(e.getMonth()+step, true)
+	case fieldYear:
+		e.setYear(e.getYear() + step)
+	case fieldHour:
+		e.setField(kind, loop(e.getField(kind)+step, 0, 23))
+	case fieldHour12:
+		e.setField(kind, loop(e.getField(kind)+step, 1, 12))
+	case fieldMinute, fieldSecond:
+		e.setField(kind, loop(e.getField(kind)+step, 0, 59))
+	case fieldAMPM:
+		f := e.mask.field(kind)
+		t := []rune(e.Text)
+		if string(t[f.start:f.end()]) == "AM" {
+			copy(t[f.start:f.end()], []rune("PM"))
+		} else {
+			copy(t[f.start:f.end()], []rune("AM"))
+		}
+		e.Text = string(t)
+	}
+}
+
+// loop returns v, wrapped around in [min, max].
+func loop(v, min, max int) int {
+	if v > max {
+		return min
+	}
+	if v < min {
+		return max
+	}
+	return v
+}
+
+func (e *maskEntry) setDay(day int, loop bool) {
+	maxDay := 30
+	switch e.getMonth() {
+	case 4, 6, 9, 11:
+		maxDay = 30
+	case 1, 3, 5, 7, 8, 10, 12:
+		maxDay = 31
+	case 2:
+		year := e.getYear()
+		if year == 0 {
+			maxDay = 28
+		} else {
+			if year%400 == 0 || (year%4 == 0 && year%100 != 0) {
+				maxDay = 29
+			} else {
+				maxDay = 28
+			}
+		}
+	}
+	if day > maxDay {
+		if loop {
+			day = 1
+		} else {
+			day = maxDay
+		}
+	}
+	if day < 1 {
+		if loop {
+			day = maxDay
+		} else {
+			day = 1
+		}
+	}
+	e.setField(fieldDay, day)
+}
+func (e *maskEntry) getDay() int {
+	return e.getField(fieldDay)
+}
+
+func (e *maskEntry) setMonth(month int, loop bool) {
+	if month > 12 {
+		if loop {
+			month = 1
+		} else {
+			month = 12
+		}
+	}
+	if month < 1 {
+		if loop {
+			month = 12
+		} else {
+			month = 1
+		}
+	}
+	e.setField(fieldMonth, month)
+}
+func (e *maskEntry) getMonth() int {
+	return e.getField(fieldMonth)
+}
+
+func (e *maskEntry) setYear(year int) {
+	if year > 9999 {
+		year = 9999
+	}
+	if year < 1 {
+		year = 1
+	}
+	e.setField(fieldYear, year)
+}
+func (e *maskEntry) getYear() int {
+	return e.getField(fieldYear)
+}
+
+// setField writes value in the field of kind, zero padded. Does nothing if there is no such field.
+func (e *maskEntry) setField(kind fieldKind, value int) {
+	if !e.mask.has(kind) {
+		return
+	}
+	f := e.mask.field(kind)
+	t := []rune(e.Text)
+	copy(t[f.start:f.end()], []rune(fmt.Sprintf("%0*d", f.width, value)))
+	e.Text = string(t)
+}
+
+// getField returns the value of the field of kind, 0 if it is not complete.
+func (e *maskEntry) getField(kind fieldKind) int {
+	if !e.mask.has(kind) {
+		return 0
+	}
+	f := e.mask.field(kind)
+	ret, err := strconv.Atoi(string([]rune(e.Text)[f.start:f.end()]))
+	if err != nil {
+		return 0
+	}
+	return ret
+}
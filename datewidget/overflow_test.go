@@ -0,0 +1,97 @@
+package main
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+)
+
+func TestDateEntry_TypedRune_Overflow(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+
+	for _, tt := range []struct {
+		layout, typed, want string
+	}{
+		{LayoutDMY, "4", "04/__/____"},        // padded, the cursor jumps to the month
+		{LayoutDMY, "42", "04/02/____"},       // both padded
+		{LayoutDMY, "35", "3_/__/____"},       // 35 is refused
+		{LayoutDMY, "3011", "30/11/____"},     //
+		{LayoutMDY, "0431", "04/3_/____"},     // no april 31
+		{LayoutDMY, "1/2/2024", "01/02/2024"}, // separators pad the field
+		{LayoutDMY, "1/2/06", "01/02/2006"},   // two-digit year
+		{LayoutDMY, "1/2/85", "01/02/1985"},   //
+		{LayoutISO, "2024-3-9", "2024-03-09"}, //
+		{LayoutTime24, "9", "09:__"},
+		{LayoutTime24, "24", "2_:__"},
+		{LayoutTime12, "1:30", "01:30 __"},
+	} {
+		var e *maskEntry
+		if d := NewDateEntry(); d.SetLayout(tt.layout) == nil {
+			e = &d.maskEntry
+		} else {
+			te := NewTimeEntry()
+			te.SetLayout(tt.layout)
+			e = &te.maskEntry
+		}
+		for _, r := range tt.typed {
+			e.TypedRune(r)
+		}
+		e.completeYear()
+		if e.Text != tt.want {
+			t.Errorf("%s %q: expected %q, got %q", tt.layout, tt.typed, tt.want, e.Text)
+		}
+	}
+}
+
+func TestDateEntry_CenturyPivot(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+
+	d := NewDateEntry()
+	d.CenturyPivot = 30
+	d.SetString("01/01/29")
+	if d.Text != "01/01/2029" {
+		t.Errorf("expected 2029, got %q", d.Text)
+	}
+	d.SetString("01/01/30")
+	if d.Text != "01/01/1930" {
+		t.Errorf("expected 1930, got %q", d.Text)
+	}
+}
+
+func TestDateEntry_TypedRune_OverComplete(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+
+	for _, tt := range []struct {
+		layout, text string
+		col          int
+		typed, want  string
+	}{
+		{LayoutDMY, "19/05/2024", 0, "3", "03/05/2024"}, // 39 is not a day: padded
+		{LayoutDMY, "19/05/2024", 0, "2", "29/05/2024"}, //
+		{LayoutDMY, "30/05/2024", 0, "0", "0_/05/2024"}, // 00 is not a day: the second digit is cleared
+		{LayoutDMY, "10/05/2024", 0, "01", "01/05/2024"},
+		{LayoutDMY, "05/10/2024", 3, "01", "05/01/2024"},
+		{LayoutDMY, "19/02/2023", 0, "3", "03/02/2023"}, // no february 30
+		{LayoutTime12, "10:30 PM", 0, "01", "01:30 PM"},
+	} {
+		var e *maskEntry
+		if d := NewDateEntry(); d.SetLayout(tt.layout) == nil {
+			e = &d.maskEntry
+		} else {
+			te := NewTimeEntry()
+			te.SetLayout(tt.layout)
+			e = &te.maskEntry
+		}
+		e.Text = tt.text
+		e.CursorColumn = tt.col
+		for _, r := range tt.typed {
+			e.TypedRune(r)
+		}
+		if e.Text != tt.want {
+			t.Errorf("%q over %q: expected %q, got %q", tt.typed, tt.text, tt.want, e.Text)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayouts are the date formats recognized by parseValue, besides the layout of the entry.
+// Only unambiguous formats are listed: 03/04/2024 is only parsed in the order of the entry layout.
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02",
+	"2006.01.02",
+	"20060102",
+	time.RFC1123Z,
+	time.RFC1123,
+	"Monday, January 2, 2006",
+	"Monday, 2 January 2006",
+	"Mon, Jan 2, 2006",
+	"Mon, 2 Jan 2006",
+	"January 2, 2006",
+	"January 2 2006",
+	"Jan 2, 2006",
+	"Jan 2 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+	"2-Jan-2006",
+	"January 2006", // the first day of the month
+	"Jan 2006",
+}
+
+// timeLayouts are the time formats recognized by parseValue, besides the layout of the entry.
+var timeLayouts = []string{
+	"15:04:05",
+	"15:04",
+	"15h04",
+	"3:04:05 PM",
+	"3:04:05PM",
+	"3:04 PM",
+	"3:04PM",
+	"3 PM",
+	"3PM",
+}
+
+// weekdays are the names of the days recognized after next and last.
+var weekdays = map[string]time.Weekday{}
+
+func init() {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		weekdays[strings.ToLower(d.String())] = d
+		weekdays[strings.ToLower(d.String()[:3])] = d
+	}
+}
+
+// parseValue recognizes a date (or a time, depending on the fields of m) written in s:
+//   - the layout of the mask, with any separator (/ - . or space) and unpadded numbers;
+//   - the formats of dateLayouts (ISO, RFC3339, month names...) or timeLayouts;
+//   - relative expressions: today, now, yesterday, tomorrow, next monday, last friday, and
+//     +3d, -2w, +1m, +1y, +2h (days, weeks, months, years, hours).
+//
+// Relative expressions are relative to now. The result is in the location of now.
+func parseValue(s string, m *mask, now time.Time) (time.Time, bool) {
+	s = strings.Join(strings.Fields(s), " ")
+	if s == "" {
+		return time.Time{}, false
+	}
+	if tm, ok := parseRelative(strings.ToLower(s), m, now); ok {
+		return tm, true
+	}
+
+	layouts := []string{m.layout}
+	if m.hasDate() {
+		// the layout of the entry, with other separators and unpadded numbers (1/2/2024)
+		short := strings.NewReplacer("02", "2", "01", "1", "03", "3").Replace(m.layout)
+		for _, l := range []string{m.layout, short} {
+			for _, sep := range []string{"/", "-", ".", " "} {
+				layouts = append(layouts, replaceSeparators(l, m, sep))
+			}
+		}
+		layouts = append(layouts, dateLayouts...)
+	} else {
+		layouts = append(layouts, timeLayouts...)
+	}
+	for _, l := range layouts {
+		value := s
+		if strings.Contains(l, "PM") {
+			value = strings.ToUpper(s) // time.Parse only knows AM and PM in upper case
+		}
+		if tm, err := time.ParseInLocation(l, value, now.Location()); err == nil {
+			return tm.In(now.Location()), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// replaceSeparators replaces the separators of the date fields in layout by sep.
+func replaceSeparators(layout string, m *mask, sep string) string {
+	var b strings.Builder
+	for _, r := range layout {
+		if m.isSeparatorRune(r) && r != ':' && r != ' ' {
+			b.WriteString(sep)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// parseRelative parses the relative expressions of parseValue, s is lower case.
+func parseRelative(s string, m *mask, now time.Time) (time.Time, bool) {
+	if !m.hasDate() {
+		// the time on January 1 of year 0, like time.Parse
+		now = time.Date(0, time.January, 1, now.Hour(), now.Minute(), now.Second(), 0, now.Location())
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch s {
+	case "now":
+		return now, true
+	case "today":
+		return today, m.hasDate()
+	case "yesterday":
+		return today.AddDate(0, 0, -1), m.hasDate()
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), m.hasDate()
+	}
+
+	if words := strings.Fields(s); len(words) == 2 && (words[0] == "next" || words[0] == "last") && m.hasDate() {
+		day, ok := weekdays[words[1]]
+		if !ok {
+			return time.Time{}, false
+		}
+		if words[0] == "next" {
+			n := (int(day)-int(today.Weekday())+6)%7 + 1 // 1 to 7 days
+			return today.AddDate(0, 0, n), true
+		}
+		n := (int(today.Weekday())-int(day)+6)%7 + 1
+		return today.AddDate(0, 0, -n), true
+	}
+
+	// +3d, -2w, +1m, +1y, +2h
+	if len(s) < 3 || (s[0] != '+' && s[0] != '-') {
+		return time.Time{}, false
+	}
+	n, err := strconv.Atoi(s[1 : len(s)-1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	if s[0] == '-' {
+		n = -n
+	}
+	switch s[len(s)-1] {
+	case 'd':
+		return today.AddDate(0, 0, n), m.hasDate()
+	case 'w':
+		return today.AddDate(0, 0, 7*n), m.hasDate()
+	case 'm':
+		return addMonths(today, n), m.hasDate()
+	case 'y':
+		return addMonths(today, 12*n), m.hasDate()
+	case 'h':
+		return now.Add(time.Duration(n) * time.Hour), true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestParseValue(t *testing.T) {
+	now := time.Date(2024, time.March, 13, 10, 30, 0, 0, time.Local) // a wednesday
+	date, _ := newDateMask(LayoutDMY)
+	clock, _ := newTimeMask(LayoutTime24)
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.Local) }
+
+	for _, tt := range []struct {
+		m    *mask
+		s    string
+		want time.Time
+	}{
+		{date, "15/03/2024", day(2024, 3, 15)},
+		{date, "15-3-2024", day(2024, 3, 15)},
+		{date, " 2024-03-15 ", day(2024, 3, 15)},
+		{date, "2024-03-15T08:00:00Z", time.Date(2024, 3, 15, 8, 0, 0, 0, time.UTC)},
+		{date, "15 March 2024", day(2024, 3, 15)},
+		{date, "march 15, 2024", day(2024, 3, 15)},
+		{date, "Friday, March 15, 2024", day(2024, 3, 15)},
+		{date, "Today", day(2024, 3, 13)},
+		{date, "yesterday", day(2024, 3, 12)},
+		{date, "+3d", day(2024, 3, 16)},
+		{date, "-2w", day(2024, 2, 28)},
+		{date, "+1m", day(2024, 4, 13)},
+		{date, "next monday", day(2024, 3, 18)},
+		{date, "next wed", day(2024, 3, 20)},
+		{date, "last friday", day(2024, 3, 8)},
+		{clock, "now", time.Date(0, 1, 1, 10, 30, 0, 0, time.Local)},
+		{clock, "9:15 pm", time.Date(0, 1, 1, 21, 15, 0, 0, time.Local)},
+		{clock, "+2h", time.Date(0, 1, 1, 12, 30, 0, 0, time.Local)},
+	} {
+		got, ok := parseValue(tt.s, tt.m, now)
+		if !ok || !got.Equal(tt.want) {
+			t.Errorf("%q: expected %v, got %v (%v)", tt.s, tt.want, got, ok)
+		}
+	}
+
+	for _, s := range []string{"", "1502", "next month", "+3x", "today"} {
+		m := date
+		if s == "today" {
+			m = clock
+		}
+		if got, ok := parseValue(s, m, now); ok {
+			t.Errorf("%q: expected no value, got %v", s, got)
+		}
+	}
+}
+
+func TestDateEntry_Paste(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+
+	d := NewDateEntry()
+	w := test.NewWindow(d)
+	t.Cleanup(w.Close)
+	var changed time.Time
+	d.OnChanged = func(tm time.Time) { changed = tm }
+
+	clipboard := w.Clipboard()
+	clipboard.SetContent("15 March 2024")
+	d.TypedShortcut(&fyne.ShortcutPaste{Clipboard: clipboard})
+	if d.Text != "15/03/2024" || !changed.Equal(time.Date(2024, 3, 15, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("unexpected paste result %q, %v", d.Text, changed)
+	}
+
+	// not a date: the digits are typed
+	d.TypedKey(&fyne.KeyEvent{Name: fyne.KeyEscape})
+	clipboard.SetContent("day 1, month 2")
+	d.TypedShortcut(&fyne.ShortcutPaste{Clipboard: clipboard})
+	if d.Text != "12/__/____" {
+		t.Errorf("unexpected paste result %q", d.Text)
+	}
+}
+
+func TestDateEntry_SetString_OnChanged(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+
+	d := NewDateEntry()
+	var changed []time.Time
+	d.OnChanged = func(tm time.Time) { changed = append(changed, tm) }
+
+	d.SetString("2024-03-15") // parsed
+	d.SetString("15032024")   // typed, the same date
+	d.SetString("tomorrow")
+	if len(changed) != 2 || !changed[0].Equal(time.Date(2024, 3, 15, 0, 0, 0, 0, time.Local)) ||
+		!changed[1].Equal(dateOf(time.Now()).AddDate(0, 0, 1)) {
+		t.Errorf("unexpected OnChanged calls %v", changed)
+	}
+}
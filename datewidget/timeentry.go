@@ -0,0 +1,74 @@
+package main
+
+import "fyne.io/fyne/v2/widget"
+
+// LayoutDateTime is the default layout of DateTimeEntry (dd/mm/yyyy HH:MM).
+const LayoutDateTime = LayoutDMY + " " + LayoutTime24
+
+// TimeEntry is a widget.Entry that only accept a time input.
+// Time format is 15:04 (HH:MM) by default, see SetLayout.
+//
+// In a 12h layout, type a or p in the AM/PM field. Up and Down toggle it.
+// GetTime returns the time on January 1 of year 0, like time.Parse.
+type TimeEntry struct {
+	maskEntry
+}
+
+// NewTimeEntry creates a new TimeEntry.
+func NewTimeEntry() *TimeEntry {
+	e := &TimeEntry{}
+	e.ExtendBaseWidget(e)
+	m, _ := newTimeMask(LayoutTime24)
+	e.init(e, m)
+	return e
+}
+
+// SetLayout changes the time format, keeping the entered time.
+// layout is a time layout (see LayoutTime24, LayoutTime24Seconds, LayoutTime12, LayoutTime12Seconds)
+// made of 15 (hour) or 03 and PM (12h hour), 04 (minutes) and optionally 05 (seconds).
+func (e *TimeEntry) SetLayout(layout string) error {
+	m, err := newTimeMask(layout)
+	if err != nil {
+		return err
+	}
+	e.setMask(m)
+	return nil
+}
+
+// DateTimeEntry is a widget.Entry that accept a date and a time input.
+// Format is 02/01/2006 15:04 (dd/mm/yyyy HH:MM) by default, see SetLayout.
+// The date can also be picked in a calendar, the entered time is kept.
+type DateTimeEntry struct {
+	maskEntry
+}
+
+// NewDateTimeEntry creates a new DateTimeEntry.
+func NewDateTimeEntry() *DateTimeEntry {
+	e := &DateTimeEntry{}
+	e.ExtendBaseWidget(e)
+	m, _ := newDateTimeMask(LayoutDateTime)
+	e.init(e, m)
+	e.ActionItem = widget.NewButtonWithIcon("", calendarIcon, e.ShowCalendar)
+	return e
+}
+
+// SetLayout changes the format, keeping the entered date and time.
+// layout must have the fields of a DateEntry layout and of a TimeEntry layout.
+func (e *DateTimeEntry) SetLayout(layout string) error {
+	m, err := newDateTimeMask(layout)
+	if err != nil {
+		return err
+	}
+	e.setMask(m)
+	return nil
+}
+
+// ShowCalendar displays a calendar under the entry, to pick a date.
+func (e *DateTimeEntry) ShowCalendar() {
+	e.showCalendar()
+}
+
+// HideCalendar hides the calendar, if displayed.
+func (e *DateTimeEntry) HideCalendar() {
+	e.hideCalendar()
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+)
+
+func TestTimeEntry_Layouts(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+
+	for layout, typed := range map[string]string{
+		LayoutTime24:        "2130",
+		LayoutTime24Seconds: "213000",
+		LayoutTime12:        "0930p",
+		LayoutTime12Seconds: "093000P",
+	} {
+		e := NewTimeEntry()
+		if err := e.SetLayout(layout); err != nil {
+			t.Fatal(err)
+		}
+		e.SetString(typed)
+		if tm := e.GetTime(); tm.Hour() != 21 || tm.Minute() != 30 {
+			t.Errorf("%s: expected 21:30, got %v (%q)", layout, tm, e.Text)
+		}
+	}
+
+	if err := NewTimeEntry().SetLayout(LayoutDMY); err == nil {
+		t.Error("expected an error for a date layout")
+	}
+}
+
+func TestTimeEntry_TypedKey(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+
+	e := NewTimeEntry()
+	e.SetLayout(LayoutTime12)
+	e.SetString("1259a")
+
+	// hours loop in 1-12
+	e.CursorColumn = 1
+	e.TypedKey(&fyne.KeyEvent{Name: fyne.KeyUp})
+	if e.Text != "01:59 AM" {
+		t.Errorf("expected the hour to loop, got %q", e.Text)
+	}
+
+	// up toggles AM/PM, digits are ignored
+	e.CursorColumn = 6
+	e.TypedKey(&fyne.KeyEvent{Name: fyne.KeyUp})
+	e.TypedRune('1')
+	if e.Text != "01:59 PM" {
+		t.Errorf("expected PM, got %q", e.Text)
+	}
+
+	// backspace clears the whole AM/PM field
+	e.CursorColumn = 8
+	e.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+	if e.Text != "01:59 __" || e.CursorColumn != 5 || !e.GetTime().IsZero() {
+		t.Errorf("unexpected backspace result %q, cursor %d", e.Text, e.CursorColumn)
+	}
+}
+
+func TestDateTimeEntry_pick(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+
+	e := NewDateTimeEntry()
+	var submitted time.Time
+	e.OnSubmitted = func(tm time.Time) { submitted = tm }
+	w := test.NewWindow(e)
+	t.Cleanup(w.Close)
+
+	// the time fields are kept
+	e.SetString("01012020 0845")
+	e.pick(time.Date(2024, time.March, 15, 0, 0, 0, 0, time.Local))
+	want := time.Date(2024, time.March, 15, 8, 45, 0, 0, time.Local)
+	if !submitted.Equal(want) {
+		t.Errorf("expected %v, got %v (%q)", want, submitted, e.Text)
+	}
+
+	// or set to midnight when empty
+	e.SetTime(time.Time{})
+	e.pick(time.Date(2024, time.March, 15, 0, 0, 0, 0, time.Local))
+	if want := time.Date(2024, time.March, 15, 0, 0, 0, 0, time.Local); !submitted.Equal(want) {
+		t.Errorf("expected %v, got %v (%q)", want, submitted, e.Text)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestDateEntry_Validate(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+
+	d := NewDateEntry()
+	d.MinDate = time.Date(2000, 1, 1, 0, 0, 0, 0, time.Local)
+	d.DateAllowed = func(tm time.Time) bool { return tm.Weekday() != time.Sunday }
+	// the text is set directly, impossible values are refused when typed
+	for text, want := range map[string]string{
+		"__/__/____": "",
+		"31/02/____": "incomplete value, expected dd/mm/yyyy",
+		"31/02/2024": "impossible date: February 2024 has 29 days",
+		"29/02/2023": "impossible date: February 2023 has 28 days",
+		"01/13/2024": "impossible date: no month 13",
+		"00/01/2024": "impossible date: no day 0",
+		"01/01/1999": "date out of range: before January 1, 2000",
+		"07/01/2024": "date not allowed: Sunday, January 7, 2024",
+		"08/01/2024": "",
+	} {
+		d.Text = text
+		got := ""
+		if err := d.Validate(); err != nil {
+			got = err.Error()
+		}
+		if got != want {
+			t.Errorf("%q: expected %q, got %q", text, want, got)
+		}
+	}
+
+	d.Required = true
+	d.SetString("")
+	if err := d.Validate(); !errors.Is(err, ErrRequired) {
+		t.Errorf("expected a required error, got %v", err)
+	}
+
+	e := NewTimeEntry()
+	e.SetLayout(LayoutTime12)
+	e.Text = "13:60 AM"
+	if err := e.Validate(); !errors.Is(err, ErrImpossibleTime) {
+		t.Errorf("expected an impossible time, got %v", err)
+	}
+}
+
+func TestDateEntry_Form(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(a.Quit)
+
+	d := NewDateEntry()
+	d.Required = true
+	var validation error
+	form := widget.NewForm(widget.NewFormItem("Date", d))
+	form.OnSubmit = func() {}
+	form.SetOnValidationChanged(func(err error) { validation = err })
+	w := test.NewWindow(form)
+	t.Cleanup(w.Close)
+
+	if form.Validate() == nil {
+		t.Error("expected the empty required date to invalidate the form")
+	}
+	d.SetString("31022024")
+	if !errors.Is(validation, ErrImpossibleDate) {
+		t.Errorf("expected the form to be notified, got %v", validation)
+	}
+	d.SetString("29022024")
+	if validation != nil || form.Validate() != nil {
+		t.Errorf("expected a valid form, got %v", validation)
+	}
+}